@@ -0,0 +1,52 @@
+package fyneadvancedlist
+
+// Roles of a list and its rows, as reported to assistive technology.
+//
+// Since: Not a core Fyne list API
+const (
+	AccessibilityRoleList     = "list"
+	AccessibilityRoleListItem = "listitem"
+)
+
+// AccessibilityInfo describes a row of a list so that assistive technology can announce it.
+// Fyne 2.4 has no accessibility API for widgets to report to, so apps which bridge to
+// a platform screen reader can read it with List.AccessibilityInfo.
+//
+// Since: Not a core Fyne list API
+type AccessibilityInfo struct {
+	Role     string // AccessibilityRoleListItem
+	Label    string // from AccessibilityLabelForItem, or TypeAheadText if not set
+	Index    int    // the position of the row among the rows shown, from 0, or -1 if it is hidden
+	Count    int    // the number of rows shown
+	Selected bool
+	Enabled  bool // see ItemEnabled
+}
+
+// AccessibilityInfo returns the accessibility metadata of the item's row.
+//
+// Since: Not a core Fyne list API
+func (l *List) AccessibilityInfo(id ListItemID) AccessibilityInfo {
+	info := AccessibilityInfo{Role: AccessibilityRoleListItem, Enabled: l.itemEnabled(id)}
+	length := l.length()
+	l.propertyLock.Lock()
+	info.Count = l.rowCount(length)
+	if row, shown := l.itemRow(id); shown && id >= 0 && id < length {
+		info.Index = row
+	} else {
+		info.Index = -1
+	}
+	for _, s := range l.selected {
+		if s == id {
+			info.Selected = true
+			break
+		}
+	}
+	l.propertyLock.Unlock()
+
+	if f := l.AccessibilityLabelForItem; f != nil {
+		info.Label = f(id)
+	} else if f := l.TypeAheadText; f != nil {
+		info.Label = f(id)
+	}
+	return info
+}
@@ -0,0 +1,104 @@
+package fyneadvancedlist
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// BindSelection keeps data in sync with the IDs of the selected items: selecting rows sets
+// data, and setting data selects rows. As the list selects one item at a time, data holds
+// at most one ID, and only the first of several IDs set on it is selected.
+// Pass nil to stop syncing.
+//
+// Since: Not a core Fyne list API
+func (l *List) BindSelection(data binding.IntList) {
+	if l.selectionBinding != nil {
+		l.selectionBinding.RemoveListener(l.selectionListener)
+	}
+	l.selectionBinding, l.selectionListener = data, nil
+	if data == nil {
+		return
+	}
+	l.updateSelectionBindings()
+	l.selectionListener = binding.NewDataListener(func() {
+		ids, err := data.Get()
+		if err != nil {
+			return
+		}
+		id := ListItemID(-1)
+		if len(ids) > 0 {
+			id = ids[0]
+		}
+		l.selectBound(id)
+	})
+	data.AddListener(l.selectionListener)
+}
+
+// BindSelected keeps data in sync with the ID of the selected item, which is -1 if no item
+// is selected: selecting a row sets data, and setting data selects the row.
+// Pass nil to stop syncing.
+//
+// Since: Not a core Fyne list API
+func (l *List) BindSelected(data binding.Int) {
+	if l.selectedBinding != nil {
+		l.selectedBinding.RemoveListener(l.selectedListener)
+	}
+	l.selectedBinding, l.selectedListener = data, nil
+	if data == nil {
+		return
+	}
+	l.updateSelectionBindings()
+	l.selectedListener = binding.NewDataListener(func() {
+		if id, err := data.Get(); err == nil {
+			l.selectBound(id)
+		}
+	})
+	data.AddListener(l.selectedListener)
+}
+
+// selects the item set on a selection binding, or nothing if id is negative
+func (l *List) selectBound(id ListItemID) {
+	current := ListItemID(-1)
+	if len(l.selected) > 0 {
+		current = l.selected[0]
+	}
+	if id == current {
+		return
+	}
+	if id < 0 {
+		l.UnselectAll()
+	} else {
+		l.Select(id)
+	}
+	// the item may not be selectable, so the bindings may need to be set back
+	l.updateSelectionBindings()
+}
+
+// sets the selection bindings, if any, to the current selection
+func (l *List) updateSelectionBindings() {
+	if l.selectionBinding == nil && l.selectedBinding == nil {
+		return
+	}
+	id := ListItemID(-1)
+	if len(l.selected) > 0 {
+		id = l.selected[0]
+	}
+	if data := l.selectionBinding; data != nil {
+		ids := []int{}
+		if id >= 0 {
+			ids = append(ids, id)
+		}
+		if old, err := data.Get(); err != nil || len(old) != len(ids) || (len(ids) > 0 && old[0] != id) {
+			if err := data.Set(ids); err != nil {
+				fyne.LogError("Failed to set the bound selection", err)
+			}
+		}
+	}
+	if data := l.selectedBinding; data != nil {
+		if old, err := data.Get(); err != nil || old != id {
+			if err := data.Set(id); err != nil {
+				fyne.LogError("Failed to set the bound selection", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package fyneadvancedlist
+
+import "sort"
+
+// CheckState is the check state of an item, see List.SetItemCheckState.
+//
+// Since: Not a core Fyne list API
+type CheckState int
+
+const (
+	// CheckUnchecked is the state of items that have not been checked.
+	//
+	// Since: Not a core Fyne list API
+	CheckUnchecked CheckState = iota
+	// CheckChecked is the state of checked items.
+	//
+	// Since: Not a core Fyne list API
+	CheckChecked
+	// CheckPartial is the state of items that are partly checked, such as a group
+	// of which only some items are checked.
+	//
+	// Since: Not a core Fyne list API
+	CheckPartial
+)
+
+// SetItemCheckState sets the check state of the item, which the list keeps with the item
+// as the list data changes through ApplyDiff, and updates its row. Rows show the state
+// through their content or accessories, such as a check box created by CreateLeadingAccessory
+// and bound to ItemCheckState by UpdateLeadingAccessory. OnCheckChanged is called if the state changed.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetItemCheckState(id ListItemID, state CheckState) {
+	if id < 0 || id >= l.length() {
+		return
+	}
+	l.propertyLock.Lock()
+	old := l.checks[id]
+	if old == state {
+		l.propertyLock.Unlock()
+		return
+	}
+	if state == CheckUnchecked {
+		delete(l.checks, id)
+	} else {
+		if l.checks == nil {
+			l.checks = make(map[ListItemID]CheckState)
+		}
+		l.checks[id] = state
+	}
+	l.propertyLock.Unlock()
+
+	l.RefreshItem(id)
+	if f := l.OnCheckChanged; f != nil {
+		f(id, state)
+	}
+}
+
+// ItemCheckState returns the check state of the item.
+//
+// Since: Not a core Fyne list API
+func (l *List) ItemCheckState(id ListItemID) CheckState {
+	l.propertyLock.RLock()
+	defer l.propertyLock.RUnlock()
+	return l.checks[id]
+}
+
+// ToggleItemChecked unchecks the item if it is checked, or checks it otherwise.
+//
+// Since: Not a core Fyne list API
+func (l *List) ToggleItemChecked(id ListItemID) {
+	if l.ItemCheckState(id) == CheckChecked {
+		l.SetItemCheckState(id, CheckUnchecked)
+	} else {
+		l.SetItemCheckState(id, CheckChecked)
+	}
+}
+
+// CheckedItems returns the IDs of the items in the given state, in order.
+// Passing CheckUnchecked returns nil, as all other items are unchecked.
+//
+// Since: Not a core Fyne list API
+func (l *List) CheckedItems(state CheckState) []ListItemID {
+	if state == CheckUnchecked {
+		return nil
+	}
+	l.propertyLock.RLock()
+	var ids []ListItemID
+	for id, s := range l.checks {
+		if s == state {
+			ids = append(ids, id)
+		}
+	}
+	l.propertyLock.RUnlock()
+	sort.Ints(ids)
+	return ids
+}
+
+// ClearCheckStates unchecks all items, without calling OnCheckChanged.
+//
+// Since: Not a core Fyne list API
+func (l *List) ClearCheckStates() {
+	l.propertyLock.Lock()
+	checks := l.checks
+	l.checks = nil
+	l.propertyLock.Unlock()
+	for id := range checks {
+		l.RefreshItem(id)
+	}
+}
+
+// moves the check states with the items after the item at from is moved to to
+func (l *List) moveChecks(from, to ListItemID) {
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	if len(l.checks) == 0 {
+		return
+	}
+	moved := make(map[ListItemID]CheckState, len(l.checks))
+	for id, state := range l.checks {
+		moved[movedItemID(id, from, to)] = state
+	}
+	l.checks = moved
+}
@@ -0,0 +1,306 @@
+package fyneadvancedlist
+
+import (
+	"sort"
+
+	"fyne.io/fyne/v2"
+)
+
+// ListMove describes an item that moved from one position in a list to another.
+//
+// Since: Not a core Fyne list API
+type ListMove struct {
+	From ListItemID // ID of the item before the change
+	To   ListItemID // ID of the item after the change
+}
+
+// ListDiff describes how the items of a list changed, using the same conventions as
+// batch updates in other toolkits: Removed and the From of each move refer to IDs before
+// the change, while Inserted, Changed and the To of each move refer to IDs after it.
+// Items that are not mentioned keep their relative order.
+//
+// Since: Not a core Fyne list API
+type ListDiff struct {
+	Inserted []ListItemID
+	Removed  []ListItemID
+	Moved    []ListMove
+	Changed  []ListItemID
+}
+
+// ComputeListDiff returns the diff that turns a list of items identified by the keys in old
+// into a list identified by the keys in new. Keys must be unique within each slice.
+// Since keys don't describe content, the returned diff has no Changed items.
+//
+// Since: Not a core Fyne list API
+func ComputeListDiff[K comparable](old, new []K) ListDiff {
+	var diff ListDiff
+	newIndex := make(map[K]int, len(new))
+	for i, k := range new {
+		newIndex[k] = i
+	}
+	oldIndex := make(map[K]int, len(old))
+	for i, k := range old {
+		oldIndex[k] = i
+		if _, ok := newIndex[k]; !ok {
+			diff.Removed = append(diff.Removed, i)
+		}
+	}
+
+	// the items in both lists, in their new order
+	var commonOld, commonNew []int
+	for i, k := range new {
+		if o, ok := oldIndex[k]; ok {
+			commonOld = append(commonOld, o)
+			commonNew = append(commonNew, i)
+		} else {
+			diff.Inserted = append(diff.Inserted, i)
+		}
+	}
+
+	// the longest run of items still in their old relative order stays put,
+	// and all others are reported as moved
+	stays := longestIncreasing(commonOld)
+	for i := range commonOld {
+		if !stays[i] {
+			diff.Moved = append(diff.Moved, ListMove{From: commonOld[i], To: commonNew[i]})
+		}
+	}
+	return diff
+}
+
+// ApplyDiff updates the list after its data changed as described by diff, instead of
+// refreshing everything. The selection, focus and item heights follow the items they
+// belong to, the scroll position stays anchored to the first visible item that was not
+// removed, and only the visible rows that show different data are updated.
+// Selected items that were removed are unselected without calling OnUnselected.
+//
+// Since: Not a core Fyne list API
+func (l *List) ApplyDiff(diff ListDiff) {
+	length := l.dataLength()
+	oldToNew, newToOld, ok := diff.mapping(length)
+	if !ok {
+		fyne.LogError("List diff does not match the list length", nil)
+		l.Refresh()
+		return
+	}
+
+	l.propertyLock.Lock()
+	l.itemHeights = remapByID(l.itemHeights, oldToNew)
+	l.measuredHeights = remapByID(l.measuredHeights, oldToNew)
+	l.collapsedSections = remapByID(l.collapsedSections, oldToNew)
+	l.checks = remapByID(l.checks, oldToNew)
+	l.pinned = remapPinned(l.pinned, oldToNew)
+	l.heightIndex.valid = false
+	l.sectionRows.valid = false
+	l.indexTargets = nil
+	l.propertyLock.Unlock()
+
+	selected := l.selected[:0]
+	for _, id := range l.selected {
+		if id < len(oldToNew) && oldToNew[id] >= 0 {
+			selected = append(selected, oldToNew[id])
+		}
+	}
+	l.selected = selected
+	l.updateSelectionBindings()
+	if f := l.currentFocus; f < len(oldToNew) {
+		l.currentFocus = nearestSurvivor(oldToNew, f, length)
+	} else if f >= length {
+		l.currentFocus = length - 1
+	}
+	if l.currentFocus < 0 {
+		l.currentFocus = 0
+	}
+
+	if l.scroller == nil || l.placeholders > 0 {
+		return // placeholder rows don't show the data
+	}
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	var oldY map[ListItemID]float32
+	oldOffset := l.offsetY
+	if l.AnimateChanges {
+		oldY = lo.visibleItemYs()
+		lo.holdRemovedRows(func(id ListItemID) bool {
+			return id < len(oldToNew) && oldToNew[id] < 0
+		})
+	}
+	anchor, inAnchor := -1, float32(0)
+	lo.renderLock.RLock()
+	for _, vis := range lo.visible {
+		if vis.id < len(oldToNew) && oldToNew[vis.id] >= 0 {
+			anchor = oldToNew[vis.id]
+			inAnchor = l.offsetY - vis.item.layoutY
+			break
+		}
+	}
+	lo.renderLock.RUnlock()
+	if anchor >= 0 {
+		l.propertyLock.Lock()
+		y := float32(l.itemOffset(anchor) + float64(inAnchor))
+		l.propertyLock.Unlock()
+		if y < 0 {
+			y = 0
+		}
+		l.setScrollOffset(y)
+		l.offsetUpdated(l.scroller.Offset)
+	}
+	l.refreshLayout()
+
+	changed := make(map[ListItemID]bool, len(diff.Changed))
+	for _, id := range diff.Changed {
+		changed[id] = true
+	}
+	staleRows := lo.takeBuffer()
+	lo.renderLock.RLock()
+	for _, vis := range lo.visible {
+		if vis.id >= len(newToOld) || newToOld[vis.id] != vis.id || changed[vis.id] {
+			staleRows = append(staleRows, vis)
+		}
+	}
+	lo.renderLock.RUnlock() // user code should not be locked
+
+	for _, vis := range staleRows {
+		lo.setupListItem(vis.item, vis.id, l.focused && l.currentFocus == vis.id)
+	}
+	lo.applyMeasuredHeights()
+
+	lo.putBuffer(staleRows)
+
+	if oldY != nil {
+		// keep the old positions relative to the viewport, which may have scrolled
+		shift := l.offsetY - oldOffset
+		for id, y := range oldY {
+			oldY[id] = y + shift
+		}
+		lo.renderLock.Lock()
+		for _, li := range lo.removedRows {
+			li.layoutY += shift
+			li.moveToLayout()
+		}
+		lo.renderLock.Unlock()
+		lo.animateRows(oldY, func(id ListItemID) (ListItemID, bool) {
+			old := newToOld[id]
+			return old, old >= 0
+		})
+	}
+}
+
+// mapping returns, for a list that now has newLen items, the new ID of each old item
+// (or -1 if removed) and the old ID of each new item (or -1 if inserted).
+// ok is false if the diff is not consistent with newLen.
+func (d ListDiff) mapping(newLen int) (oldToNew, newToOld []int, ok bool) {
+	const unassigned = -2
+	oldLen := newLen + len(d.Removed) - len(d.Inserted)
+	if newLen < 0 || oldLen < 0 {
+		return nil, nil, false
+	}
+	oldToNew = make([]int, oldLen)
+	for i := range oldToNew {
+		oldToNew[i] = unassigned
+	}
+	newToOld = make([]int, newLen)
+	for i := range newToOld {
+		newToOld[i] = unassigned
+	}
+
+	for _, id := range d.Removed {
+		if id < 0 || id >= oldLen {
+			return nil, nil, false
+		}
+		oldToNew[id] = -1
+	}
+	for _, id := range d.Inserted {
+		if id < 0 || id >= newLen {
+			return nil, nil, false
+		}
+		newToOld[id] = -1
+	}
+	for _, m := range d.Moved {
+		if m.From < 0 || m.From >= oldLen || m.To < 0 || m.To >= newLen {
+			return nil, nil, false
+		}
+		oldToNew[m.From] = m.To
+		newToOld[m.To] = m.From
+	}
+
+	// the remaining items fill the remaining positions in their original order
+	next := 0
+	for o := range oldToNew {
+		if oldToNew[o] != unassigned {
+			continue
+		}
+		for next < newLen && newToOld[next] != unassigned {
+			next++
+		}
+		if next >= newLen {
+			return nil, nil, false
+		}
+		oldToNew[o] = next
+		newToOld[next] = o
+	}
+	for n := range newToOld {
+		if newToOld[n] == unassigned {
+			return nil, nil, false
+		}
+	}
+	return oldToNew, newToOld, true
+}
+
+// returns the values, such as item heights, keyed by the new IDs of their items
+func remapByID[V any](values map[ListItemID]V, oldToNew []int) map[ListItemID]V {
+	if len(values) == 0 {
+		return values
+	}
+	remapped := make(map[ListItemID]V, len(values))
+	for id, h := range values {
+		if id < len(oldToNew) && oldToNew[id] >= 0 {
+			remapped[oldToNew[id]] = h
+		}
+	}
+	return remapped
+}
+
+// returns the new ID of the item at id, or if it was removed, of the nearest item after it
+// that is still in the list, or else before it. It returns the last of the length new items
+// if none of the old items are left.
+func nearestSurvivor(oldToNew []int, id ListItemID, length int) ListItemID {
+	for after := id; after < len(oldToNew); after++ {
+		if oldToNew[after] >= 0 {
+			return oldToNew[after]
+		}
+	}
+	for before := id - 1; before >= 0; before-- {
+		if oldToNew[before] >= 0 {
+			return oldToNew[before]
+		}
+	}
+	return length - 1
+}
+
+// returns which elements of seq are part of a longest strictly increasing subsequence
+func longestIncreasing(seq []int) []bool {
+	// tails[k] is the index in seq of the smallest tail of an increasing run of length k+1
+	tails := make([]int, 0, len(seq))
+	prev := make([]int, len(seq))
+	for i, v := range seq {
+		k := sort.Search(len(tails), func(j int) bool { return seq[tails[j]] >= v })
+		if k > 0 {
+			prev[i] = tails[k-1]
+		} else {
+			prev[i] = -1
+		}
+		if k == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[k] = i
+		}
+	}
+
+	in := make([]bool, len(seq))
+	if len(tails) > 0 {
+		for i := tails[len(tails)-1]; i >= 0; i = prev[i] {
+			in[i] = true
+		}
+	}
+	return in
+}
@@ -0,0 +1,146 @@
+package fyneadvancedlist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputeListDiff(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		old, new []string
+		want     ListDiff
+	}{
+		{name: "empty", want: ListDiff{}},
+		{name: "to empty", old: []string{"a", "b"}, want: ListDiff{Removed: []ListItemID{0, 1}}},
+		{name: "from empty", new: []string{"a", "b"}, want: ListDiff{Inserted: []ListItemID{0, 1}}},
+		{name: "unchanged", old: []string{"a", "b", "c"}, new: []string{"a", "b", "c"}, want: ListDiff{}},
+		{name: "insert", old: []string{"a", "c"}, new: []string{"a", "b", "c"}, want: ListDiff{Inserted: []ListItemID{1}}},
+		{name: "remove", old: []string{"a", "b", "c"}, new: []string{"a", "c"}, want: ListDiff{Removed: []ListItemID{1}}},
+		{
+			name: "move",
+			old:  []string{"a", "b", "c", "d"}, new: []string{"b", "c", "a", "d"},
+			want: ListDiff{Moved: []ListMove{{From: 0, To: 2}}},
+		},
+		{
+			name: "mixed",
+			old:  []string{"a", "b", "c", "d"}, new: []string{"d", "a", "e", "c"},
+			want: ListDiff{Inserted: []ListItemID{2}, Removed: []ListItemID{1}, Moved: []ListMove{{From: 3, To: 0}}},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ComputeListDiff(tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ComputeListDiff(%v, %v) = %+v, want %+v", tt.old, tt.new, got, tt.want)
+			}
+			checkDiffApplies(t, tt.old, tt.new, got)
+		})
+	}
+}
+
+func TestComputeListDiff_DuplicateKeys(t *testing.T) {
+	// keys should be unique, but duplicates must still give a diff that matches the lengths
+	for _, tt := range []struct{ old, new []string }{
+		{old: []string{"a", "a"}, new: []string{"a", "a"}},
+		{old: []string{"a", "b", "a"}, new: []string{"b", "a"}},
+		{old: []string{"a"}, new: []string{"a", "a", "b"}},
+	} {
+		diff := ComputeListDiff(tt.old, tt.new)
+		if _, _, ok := diff.mapping(len(tt.new)); !ok {
+			t.Errorf("ComputeListDiff(%v, %v) = %+v, which does not match the lengths", tt.old, tt.new, diff)
+		}
+	}
+}
+
+// checks that the mapping of diff turns old into new
+func checkDiffApplies(t *testing.T, old, new []string, diff ListDiff) {
+	t.Helper()
+	oldToNew, newToOld, ok := diff.mapping(len(new))
+	if !ok {
+		t.Fatalf("mapping of %+v for %d items failed", diff, len(new))
+	}
+	for n, o := range newToOld {
+		if o >= 0 && old[o] != new[n] {
+			t.Errorf("new item %d (%s) maps to old item %d (%s)", n, new[n], o, old[o])
+		}
+		if o >= 0 && oldToNew[o] != n {
+			t.Errorf("old item %d maps to %d, want %d", o, oldToNew[o], n)
+		}
+	}
+}
+
+func TestListDiff_Mapping(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		diff               ListDiff
+		newLen             int
+		oldToNew, newToOld []int
+		ok                 bool
+	}{
+		{name: "empty", ok: true, oldToNew: []int{}, newToOld: []int{}},
+		{name: "no change", newLen: 2, ok: true, oldToNew: []int{0, 1}, newToOld: []int{0, 1}},
+		{name: "insert", diff: ListDiff{Inserted: []ListItemID{0}}, newLen: 2, ok: true, oldToNew: []int{1}, newToOld: []int{-1, 0}},
+		{name: "remove", diff: ListDiff{Removed: []ListItemID{1}}, newLen: 2, ok: true, oldToNew: []int{0, -1, 1}, newToOld: []int{0, 2}},
+		{
+			name: "move", diff: ListDiff{Moved: []ListMove{{From: 0, To: 2}}}, newLen: 3, ok: true,
+			oldToNew: []int{2, 0, 1}, newToOld: []int{1, 2, 0},
+		},
+		{name: "too many removed", diff: ListDiff{Removed: []ListItemID{0, 1}}, newLen: -1},
+		{name: "removed out of range", diff: ListDiff{Removed: []ListItemID{3}}, newLen: 2},
+		{name: "inserted out of range", diff: ListDiff{Inserted: []ListItemID{2}}, newLen: 2},
+		{name: "moved out of range", diff: ListDiff{Moved: []ListMove{{From: 0, To: 5}}}, newLen: 2},
+		{name: "moved onto an insert", diff: ListDiff{Inserted: []ListItemID{1}, Moved: []ListMove{{From: 0, To: 1}}}, newLen: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oldToNew, newToOld, ok := tt.diff.mapping(tt.newLen)
+			if ok != tt.ok {
+				t.Fatalf("mapping ok = %v, want %v", ok, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if !reflect.DeepEqual(oldToNew, tt.oldToNew) || !reflect.DeepEqual(newToOld, tt.newToOld) {
+				t.Errorf("mapping = %v, %v, want %v, %v", oldToNew, newToOld, tt.oldToNew, tt.newToOld)
+			}
+		})
+	}
+}
+
+func TestLongestIncreasing(t *testing.T) {
+	for _, tt := range []struct {
+		seq  []int
+		want []bool
+	}{
+		{seq: nil, want: []bool{}},
+		{seq: []int{3}, want: []bool{true}},
+		{seq: []int{0, 1, 2}, want: []bool{true, true, true}},
+		{seq: []int{2, 1, 0}, want: []bool{false, false, true}},
+		{seq: []int{3, 0, 1, 2}, want: []bool{false, true, true, true}},
+		{seq: []int{1, 2, 0, 3}, want: []bool{true, true, false, true}},
+		{seq: []int{1, 1, 2}, want: []bool{false, true, true}},
+	} {
+		if got := longestIncreasing(tt.seq); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("longestIncreasing(%v) = %v, want %v", tt.seq, got, tt.want)
+		}
+	}
+}
+
+func TestNearestSurvivor(t *testing.T) {
+	oldToNew := []int{0, -1, -1, 1, -1}
+	for _, tt := range []struct {
+		id, want ListItemID
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 1},
+		{3, 1},
+		{4, 1},
+	} {
+		if got := nearestSurvivor(oldToNew, tt.id, 2); got != tt.want {
+			t.Errorf("nearestSurvivor(%d) = %d, want %d", tt.id, got, tt.want)
+		}
+	}
+	if got := nearestSurvivor([]int{-1, -1}, 0, 0); got != -1 {
+		t.Errorf("nearestSurvivor with no items left = %d, want -1", got)
+	}
+}
@@ -0,0 +1,98 @@
+package fyneadvancedlist
+
+import "fyne.io/fyne/v2"
+
+// BeginEdit shows the template from CreateEditItem in place of the content of the item's row,
+// scrolling it into view and moving the keyboard focus into it. An edit in progress is committed first.
+//
+// Since: Not a core Fyne list API
+func (l *List) BeginEdit(id ListItemID) {
+	if l.CreateEditItem == nil || id < 0 || id >= l.length() || l.isPlaceholder(id) || !l.itemEnabled(id) {
+		return
+	}
+	l.EndEdit(true)
+	l.editor, l.editingID = l.CreateEditItem(id), id
+	l.moveFocus(id)
+	l.RefreshItem(id)
+	if c := fyne.CurrentApp().Driver().CanvasForObject(l); c != nil && !l.focused {
+		c.Focus(l)
+	}
+	l.enterRow()
+}
+
+// EndEdit ends the edit in progress, if any, calling CommitEdit if commit is true or CancelEdit,
+// and shows the content of the row again.
+//
+// Since: Not a core Fyne list API
+func (l *List) EndEdit(commit bool) {
+	editor, id := l.editor, l.editingID
+	if editor == nil {
+		return
+	}
+	l.exitRow()
+	l.editor = nil
+	f := l.CancelEdit
+	if commit {
+		f = l.CommitEdit
+	}
+	if f != nil {
+		f(id, editor)
+	}
+	l.RefreshItem(id)
+}
+
+// handles Enter and Escape typed in the editor of the item being edited,
+// returning false for other keys
+func (l *List) typedEditKey(e *fyne.KeyEvent) bool {
+	if l.editor == nil || l.currentFocus != l.editingID {
+		return false
+	}
+	switch e.Name {
+	case fyne.KeyReturn, fyne.KeyEnter:
+		l.EndEdit(true)
+	case fyne.KeyEscape:
+		l.EndEdit(false)
+	default:
+		return false
+	}
+	return true
+}
+
+// shows the editor in place of the row content if the row shows the item being edited,
+// or the row content
+func (li *listItem) syncEditor() {
+	list := li.listLayout.list
+	editor := list.editor
+	if li.id != list.editingID {
+		editor = nil
+	}
+	if li.editor == editor || li.content == nil {
+		return
+	}
+	if li.editor != nil {
+		li.content.Remove(li.editor)
+		li.child.Show()
+		if li.listLayout.editorRow == li {
+			li.listLayout.editorRow = nil
+		}
+	}
+	li.editor = editor
+	if editor != nil {
+		if other := li.listLayout.editorRow; other != nil && other != li {
+			other.content.Remove(editor) // the row that showed it has been recycled
+			other.editor = nil
+			other.child.Show()
+		}
+		li.listLayout.editorRow = li
+		li.child.Hide()
+		objects := make([]fyne.CanvasObject, 0, len(li.content.Objects)+1)
+		for _, o := range li.content.Objects {
+			objects = append(objects, o)
+			if o == li.child {
+				objects = append(objects, editor)
+			}
+		}
+		li.content.Objects = objects
+	}
+	li.content.Refresh()
+}
@@ -0,0 +1,130 @@
+package fyneadvancedlist
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SetEditMode turns the edit mode of the list on or off. Edit mode is the touch screen pattern
+// for batch actions: each row shows a circle marking whether it is selected, and tapping a row
+// adds it to or removes it from the selection instead of selecting only it. If dragging is enabled,
+// the rows that can be dragged show a grip, which reorders them without a long press.
+// Turning edit mode off clears the selection.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetEditMode(editing bool) {
+	if l.editMode == editing {
+		return
+	}
+	l.editMode = editing
+	if !editing {
+		l.UnselectAll()
+	}
+	l.Refresh()
+}
+
+// EditMode returns whether the list is in edit mode, see SetEditMode.
+//
+// Since: Not a core Fyne list API
+func (l *List) EditMode() bool {
+	return l.editMode
+}
+
+// adds the item to the selection, keeping the items already selected, as in edit mode
+func (l *List) selectAlso(id ListItemID) {
+	if id < 0 || id >= l.length() || l.isPlaceholder(id) || l.selectedIndex(id) >= 0 {
+		return
+	}
+	l.selected = append(l.selected[:len(l.selected):len(l.selected)], id)
+	l.RefreshRange(id, id)
+	l.updateSelectionBindings()
+	if f := l.OnSelected; f != nil {
+		f(id)
+	}
+}
+
+// selects the item if it is not selected, or unselects it, keeping the other items selected
+func (l *List) toggleSelected(id ListItemID) {
+	if l.selectedIndex(id) >= 0 {
+		l.Unselect(id)
+	} else {
+		l.selectAlso(id)
+	}
+}
+
+// returns the index of the item in the selection, or -1 if it is not selected
+func (l *List) selectedIndex(id ListItemID) int {
+	return indexOfItem(l.selected, id)
+}
+
+// returns the index of the item in ids, or -1 if it is not there
+func indexOfItem(ids []ListItemID, id ListItemID) int {
+	for i, s := range ids {
+		if s == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// creates the selection mark shown by the row in edit mode, and its grip
+func (li *listItem) createEditMarks() {
+	li.editMark, li.editMarkIcon = newEditMark()
+	li.grip = container.NewPadded(widget.NewIcon(theme.MenuIcon()))
+	li.grip.Hide()
+}
+
+// returns a hidden mark showing whether an item is selected in edit mode, and its icon
+func newEditMark() (*fyne.Container, *widget.Icon) {
+	icon := widget.NewIcon(theme.RadioButtonIcon())
+	mark := container.NewPadded(icon)
+	mark.Hide()
+	return mark, icon
+}
+
+// shows whether the item is selected by the icon of its edit mark
+func setEditMarkIcon(icon *widget.Icon, selected bool) {
+	resource := theme.RadioButtonIcon()
+	if selected {
+		resource = theme.RadioButtonCheckedIcon()
+	}
+	if icon.Resource != resource {
+		icon.SetResource(resource)
+	}
+}
+
+// shows or hides the selection mark and the grip of the row
+func (li *listItem) refreshEditMarks() {
+	changed := li.editMark.Visible() != li.editing || li.grip.Visible() != li.showGrip
+	if li.editing {
+		setEditMarkIcon(li.editMarkIcon, li.selected)
+		li.editMark.Show()
+	} else {
+		li.editMark.Hide()
+	}
+	if li.showGrip {
+		li.grip.Show()
+	} else {
+		li.grip.Hide()
+	}
+	if changed {
+		li.content.Refresh() // the row content is narrower or wider
+	}
+}
+
+// returns whether a drag of the row can reorder it, rather than scroll the list.
+// Rows showing a grip are only dragged by it, without a long press.
+func (li *listItem) canStartDrag(e *fyne.DragEvent) bool {
+	l := li.listLayout
+	if !l.canDragRow(li.id) {
+		return false
+	}
+	if li.grip.Visible() {
+		start := e.Position.Subtract(e.Dragged)
+		pos, size := li.grip.Position(), li.grip.Size()
+		return start.X >= pos.X && start.X < pos.X+size.Width && start.Y >= pos.Y && start.Y < pos.Y+size.Height
+	}
+	return !l.requiresLongPress() || li.dragArmed
+}
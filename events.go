@@ -0,0 +1,57 @@
+package fyneadvancedlist
+
+import (
+	"sync/atomic"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// runs f on the goroutine which delivers the input events of the window showing the list,
+// so that code started by timers doesn't race with the event handlers of the list.
+// Fyne 2.4 has no API for this, so f is queued with the QueueEvent method of the driver's
+// windows, or run straight away if the list isn't shown or the driver has no event queue,
+// as with the test driver.
+func (l *List) runOnEventQueue(f func()) {
+	d := fyne.CurrentApp().Driver()
+	if c := d.CanvasForObject(l); c != nil {
+		for _, w := range d.AllWindows() {
+			if w.Canvas() != c {
+				continue
+			}
+			if q, ok := w.(interface{ QueueEvent(func()) }); ok {
+				q.QueueEvent(f)
+				return
+			}
+			break
+		}
+	}
+	f()
+}
+
+// eventTimer calls a function on the event goroutine of a list after a delay, see runOnEventQueue.
+// Unlike with a time.Timer, the function is not called once Stop has returned,
+// even if the delay has already passed and the call is queued.
+type eventTimer struct {
+	timer   *time.Timer
+	stopped atomic.Bool
+}
+
+// calls f on the event goroutine of the list after the delay, unless the timer is stopped first
+func (l *List) afterDelay(delay time.Duration, f func()) *eventTimer {
+	t := &eventTimer{}
+	t.timer = time.AfterFunc(delay, func() {
+		l.runOnEventQueue(func() {
+			if !t.stopped.Load() {
+				f()
+			}
+		})
+	})
+	return t
+}
+
+// Stop prevents the function of the timer from being called, if it hasn't been yet.
+func (t *eventTimer) Stop() {
+	t.stopped.Store(true)
+	t.timer.Stop()
+}
@@ -0,0 +1,715 @@
+package fyneadvancedlist
+
+import (
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*GridWrapList)(nil)
+
+// GridWrapList is a widget that pools items for performance and wraps them into as many
+// columns as fit its width, like widget.GridWrap, inside of a vertical scroller.
+// Unlike widget.GridWrap, its items can be reordered by dragging them, and several items
+// can be selected in edit mode, as with List. The arrow keys move the keyboard focus
+// between items, and Space selects the focused item.
+//
+// Since: Not a core Fyne list API
+type GridWrapList struct {
+	widget.BaseWidget
+
+	Length       func() int                                  `json:"-"`
+	CreateItem   func() fyne.CanvasObject                    `json:"-"`
+	UpdateItem   func(id ListItemID, item fyne.CanvasObject) `json:"-"`
+	OnSelected   func(id ListItemID)                         `json:"-"`
+	OnUnselected func(id ListItemID)                         `json:"-"`
+
+	// ItemSize, if set, is the size of every item instead of the MinSize of the template
+	// returned by CreateItem. Call Refresh after changing it while the grid is visible.
+	ItemSize fyne.Size
+
+	// Enable drag-and-drop of items within the grid. These work as for List,
+	// except that the insertion indicator is always a line between items.
+	// DragIndicator.Mode is ignored.
+	EnableDragging     bool
+	OnDragEnd          func(draggedFrom, draggedTo ListItemID) `json:"-"`
+	OnDragBegin        func(id ListItemID)                     `json:"-"`
+	CanDragItem        func(id ListItemID) bool                `json:"-"`
+	DragStartThreshold float32
+	DragScroll         DragScrollConfig
+	DragIndicator      DragIndicatorStyle
+
+	list           *List // shows a row of the grid in each of its rows
+	reorderAdapter ReorderableAdapter
+	selected       []ListItemID
+	editMode       bool
+	currentFocus   ListItemID // item with the keyboard focus when the list of rows is focused
+	itemMin        fyne.Size
+	columns        int
+
+	drag         reorderDrag   // threshold, ghost, indicator and scrolling shared with List
+	dragging     ListItemID    // -1 if no drag
+	dragPos      fyne.Position // pointer position relative to the grid
+	dragInsertAt ListItemID
+}
+
+// NewGridWrapList creates and returns a grid widget for displaying items that wrap
+// into rows, with scrolling and caching for performance.
+//
+// Since: Not a core Fyne list API
+func NewGridWrapList(length func() int, createItem func() fyne.CanvasObject, updateItem func(ListItemID, fyne.CanvasObject)) *GridWrapList {
+	g := &GridWrapList{Length: length, CreateItem: createItem, UpdateItem: updateItem, columns: 1, dragging: -1}
+	g.ExtendBaseWidget(g)
+	return g
+}
+
+// NewReorderableGridWrapList creates a grid with dragging enabled that displays the items of adapter.
+// When an item is dropped the grid calls adapter.Move, keeps the selection on the moved item
+// and refreshes itself, before calling OnDragEnd if set.
+//
+// Since: Not a core Fyne list API
+func NewReorderableGridWrapList(adapter ReorderableAdapter, createItem func() fyne.CanvasObject, updateItem func(ListItemID, fyne.CanvasObject)) *GridWrapList {
+	g := NewGridWrapList(adapter.Len, createItem, updateItem)
+	g.EnableDragging = true
+	g.reorderAdapter = adapter
+	return g
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (g *GridWrapList) CreateRenderer() fyne.WidgetRenderer {
+	g.ExtendBaseWidget(g)
+	g.updateItemMin()
+
+	g.list = NewList(g.rowCount, func() fyne.CanvasObject { return newGridRow(g) }, g.updateRow)
+	g.list.HideSeparators = true
+	g.list.keyHandler = g.typedKey
+	g.list.cellFocus = true
+	g.list.currentFocus = g.currentFocus / g.columns
+	g.drag.init(true, g.dragScrollStep)
+	g.drag.refreshStyle(g.DragIndicator)
+	return &gridWrapListRenderer{grid: g, objects: []fyne.CanvasObject{g.list, g.drag.ghost, g.drag.indicator}}
+}
+
+// RefreshItem refreshes a single item, specified by the item ID passed in.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) RefreshItem(id ListItemID) {
+	if g.list == nil {
+		return
+	}
+	row := id / g.columns
+	g.list.RefreshRange(row, row)
+}
+
+// ScrollTo scrolls to the item represented by id.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) ScrollTo(id ListItemID) {
+	if g.list == nil || id < 0 || id >= g.length() {
+		return
+	}
+	g.list.ScrollTo(id / g.columns)
+}
+
+// ScrollToTop scrolls to the start of the grid.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) ScrollToTop() {
+	if g.list != nil {
+		g.list.ScrollToTop()
+	}
+}
+
+// ScrollToBottom scrolls to the end of the grid.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) ScrollToBottom() {
+	if g.list != nil {
+		g.list.ScrollToBottom()
+	}
+}
+
+// Select adds the item identified by the given ID to the selection.
+// Outside of edit mode, the other items are unselected.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) Select(id ListItemID) {
+	if id < 0 || id >= g.length() {
+		return
+	}
+	if g.editMode {
+		g.selectAlso(id)
+		return
+	}
+	if len(g.selected) == 1 && id == g.selected[0] {
+		return
+	}
+	old := g.selected
+	g.selected = []ListItemID{id}
+	defer func() {
+		if f := g.OnUnselected; f != nil {
+			for _, o := range old {
+				if o != id {
+					f(o)
+				}
+			}
+		}
+		if f := g.OnSelected; f != nil && indexOfItem(old, id) < 0 {
+			f(id)
+		}
+	}()
+	if g.list != nil && g.list.scroller != nil {
+		g.list.scrollTo(id / g.columns)
+		g.list.scroller.Refresh() // move the content to the new scroll offset
+	}
+	for _, o := range old {
+		g.RefreshItem(o)
+	}
+	g.RefreshItem(id)
+}
+
+// Unselect removes the item identified by the given ID from the selection.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) Unselect(id ListItemID) {
+	i := indexOfItem(g.selected, id)
+	if i < 0 {
+		return
+	}
+
+	g.selected = append(g.selected[:i:i], g.selected[i+1:]...)
+	g.RefreshItem(id)
+	if f := g.OnUnselected; f != nil {
+		f(id)
+	}
+}
+
+// UnselectAll removes all items from the selection.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) UnselectAll() {
+	if len(g.selected) == 0 {
+		return
+	}
+
+	selected := g.selected
+	g.selected = nil
+	for _, id := range selected {
+		g.RefreshItem(id)
+	}
+	if f := g.OnUnselected; f != nil {
+		for _, id := range selected {
+			f(id)
+		}
+	}
+}
+
+// SetEditMode turns the edit mode of the grid on or off. As with List, each item shows a
+// mark in edit mode, and tapping an item adds it to or removes it from the selection
+// instead of selecting only it. Turning edit mode off clears the selection.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) SetEditMode(editing bool) {
+	if g.editMode == editing {
+		return
+	}
+	g.editMode = editing
+	if !editing {
+		g.UnselectAll()
+	}
+	g.Refresh()
+}
+
+// EditMode returns whether the grid is in edit mode, see SetEditMode.
+//
+// Since: Not a core Fyne list API
+func (g *GridWrapList) EditMode() bool {
+	return g.editMode
+}
+
+// adds the item to the selection, keeping the items already selected, as in edit mode
+func (g *GridWrapList) selectAlso(id ListItemID) {
+	if indexOfItem(g.selected, id) >= 0 {
+		return
+	}
+	g.selected = append(g.selected[:len(g.selected):len(g.selected)], id)
+	g.RefreshItem(id)
+	if f := g.OnSelected; f != nil {
+		f(id)
+	}
+}
+
+// selects the item if it is not selected, or unselects it, keeping the other items selected
+func (g *GridWrapList) toggleSelected(id ListItemID) {
+	if indexOfItem(g.selected, id) >= 0 {
+		g.Unselect(id)
+	} else {
+		g.selectAlso(id)
+	}
+}
+
+// handles the keys that move the focus between items and select them,
+// before the list showing the rows of the grid
+func (g *GridWrapList) typedKey(e *fyne.KeyEvent) bool {
+	length := g.length()
+	switch e.Name {
+	case fyne.KeyLeft:
+		g.moveFocus(g.currentFocus - 1)
+	case fyne.KeyRight:
+		g.moveFocus(g.currentFocus + 1)
+	case fyne.KeyUp:
+		g.moveFocus(g.currentFocus - g.columns)
+	case fyne.KeyDown:
+		g.moveFocus(g.currentFocus + g.columns)
+	case fyne.KeyPageUp, fyne.KeyPageDown:
+		rows := int(g.list.Size().Height / (g.itemMin.Height + theme.Padding()))
+		if rows < 1 {
+			rows = 1
+		}
+		if e.Name == fyne.KeyPageUp {
+			rows = -rows
+		}
+		id := g.currentFocus + rows*g.columns
+		if id < 0 {
+			id = g.currentFocus % g.columns
+		} else if id >= length {
+			id = length - 1
+		}
+		g.moveFocus(id)
+	case fyne.KeyHome:
+		g.moveFocus(0)
+	case fyne.KeyEnd:
+		g.moveFocus(length - 1)
+	case fyne.KeySpace:
+		if g.currentFocus >= length {
+			return true
+		}
+		if g.editMode {
+			g.toggleSelected(g.currentFocus)
+		} else {
+			g.Select(g.currentFocus)
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// moves the keyboard focus to the item, scrolling it into view
+func (g *GridWrapList) moveFocus(id ListItemID) {
+	if id < 0 || id >= g.length() || id == g.currentFocus {
+		return
+	}
+	old := g.currentFocus
+	g.currentFocus = id
+	g.list.currentFocus = id / g.columns
+	g.RefreshItem(old)
+	g.ScrollTo(id)
+	g.RefreshItem(id)
+}
+
+func (g *GridWrapList) length() int {
+	if f := g.Length; f != nil {
+		return f()
+	}
+	return 0
+}
+
+// returns the number of rows of the grid, for its list
+func (g *GridWrapList) rowCount() int {
+	return (g.length() + g.columns - 1) / g.columns
+}
+
+func (g *GridWrapList) updateItemMin() {
+	if !g.ItemSize.IsZero() {
+		g.itemMin = g.ItemSize
+	} else if f := g.CreateItem; f != nil {
+		g.itemMin = f().MinSize()
+	}
+}
+
+// returns the number of columns of items that fit in the width
+func (g *GridWrapList) columnsForWidth(width float32) int {
+	padding := theme.Padding()
+	columns := int((width + padding) / (g.itemMin.Width + padding))
+	if columns < 1 {
+		return 1
+	}
+	return columns
+}
+
+// binds the items of a row of the grid
+func (g *GridWrapList) updateRow(row ListItemID, o fyne.CanvasObject) {
+	r := o.(*gridRow)
+	for len(r.box.Objects) < g.columns {
+		f := g.CreateItem
+		if f == nil {
+			return
+		}
+		r.box.Add(newGridCell(f(), g))
+	}
+	length := g.length()
+	for col, obj := range r.box.Objects {
+		cell := obj.(*gridCell)
+		id := row*g.columns + col
+		if col >= g.columns || id >= length {
+			cell.Hide()
+			continue
+		}
+		cell.Show()
+		g.setupCell(cell, id)
+	}
+}
+
+func (g *GridWrapList) setupCell(cell *gridCell, id ListItemID) {
+	cell.id = id
+	selected := indexOfItem(g.selected, id) >= 0
+	focused := g.list.focused && id == g.currentFocus
+	if selected != cell.selected || focused != cell.focused || g.editMode != cell.editing {
+		cell.selected, cell.focused, cell.editing = selected, focused, g.editMode
+		cell.Refresh()
+	}
+	if f := g.UpdateItem; f != nil {
+		f(id, cell.child)
+	}
+}
+
+func (g *GridWrapList) canDragItem(id ListItemID) bool {
+	if !g.EnableDragging {
+		return false
+	}
+	if f := g.CanDragItem; f != nil {
+		return f(id)
+	}
+	return true
+}
+
+func (g *GridWrapList) onCellDragged(cell *gridCell, e *fyne.DragEvent) {
+	startedDrag := false
+	if g.dragging < 0 /*no drag in progress*/ {
+		if !g.drag.passedThreshold(e, g.DragStartThreshold) {
+			return
+		}
+		g.dragging = cell.id
+		startedDrag = true
+		g.startDragGhost(cell)
+	}
+
+	g.dragPos = e.AbsolutePosition.Subtract(fyne.CurrentApp().Driver().AbsolutePositionForObject(g))
+	cfg := g.DragScroll.withDefaults(g.itemMin.Height)
+	g.drag.scrollAt(cfg.speed(g.dragPos.Y, g.Size().Height))
+	g.updateDragIndicator()
+	if startedDrag && g.OnDragBegin != nil {
+		g.OnDragBegin(g.dragging)
+	}
+}
+
+func (g *GridWrapList) onDragEnd() {
+	g.drag.pending = false
+	if g.dragging < 0 {
+		return
+	}
+	from := g.dragging
+	g.dragging = -1
+	g.drag.end()
+	if g.reorderAdapter != nil {
+		if to := movedToIndex(from, g.dragInsertAt); to != from {
+			g.reorderAdapter.Move(from, to)
+			for i, id := range g.selected {
+				g.selected[i] = movedItemID(id, from, to)
+			}
+			g.currentFocus = movedItemID(g.currentFocus, from, to)
+			g.list.currentFocus = g.currentFocus / g.columns
+			g.list.Refresh()
+		}
+	}
+	if g.OnDragEnd != nil {
+		g.OnDragEnd(from, g.dragInsertAt)
+	}
+}
+
+// works out where the dragged item would be dropped and moves the indicator and ghost there
+func (g *GridWrapList) updateDragIndicator() {
+	padding := theme.Padding()
+	cellWidth, cellHeight := g.itemMin.Width+padding, g.itemMin.Height+padding
+	offset := g.list.GetScrollOffset()
+	length := g.length()
+
+	row := int(math.Floor(float64((g.dragPos.Y + offset) / cellHeight)))
+	if rows := g.rowCount(); row >= rows {
+		row = rows - 1
+	}
+	if row < 0 {
+		row = 0
+	}
+	col := int(math.Round(float64(g.dragPos.X / cellWidth)))
+	if col > g.columns {
+		col = g.columns
+	} else if col < 0 {
+		col = 0
+	}
+	g.dragInsertAt = row*g.columns + col
+	if g.dragInsertAt > length {
+		g.dragInsertAt = length
+		col = length - row*g.columns
+	}
+
+	top := float32(row)*cellHeight - offset
+	_, across := g.drag.layoutIndicator(g.DragIndicator.withDefaults(), g.itemMin.Height)
+	g.drag.indicator.Move(fyne.NewPos(float32(col)*cellWidth-(padding+across)/2, top))
+	if top+g.itemMin.Height < 0 || top > g.Size().Height {
+		g.drag.indicator.Hide()
+	} else {
+		g.drag.indicator.Show()
+	}
+
+	// keep the ghost clipped to the bounds of the grid
+	pos := g.dragPos.Subtract(g.drag.pressPos)
+	max := g.Size().Subtract(g.drag.ghost.Size())
+	pos.X = fyne.Max(fyne.Min(pos.X, max.Width), 0)
+	pos.Y = fyne.Max(fyne.Min(pos.Y, max.Height), 0)
+	g.drag.ghost.Move(pos)
+}
+
+func (g *GridWrapList) startDragGhost(cell *gridCell) {
+	ghost := g.drag.ghostCopy(g.CreateItem)
+	if ghost == nil {
+		return
+	}
+	if f := g.UpdateItem; f != nil {
+		f(cell.id, ghost)
+	}
+	g.drag.ghost.Resize(cell.Size())
+	g.drag.ghost.Show()
+}
+
+// scrolls the grid for a frame while a dragged item is near its top or bottom
+func (g *GridWrapList) dragScrollStep(speed float32) {
+	g.list.scroller.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.Delta{DY: -speed}})
+	g.updateDragIndicator()
+}
+
+// Declare conformity with WidgetRenderer interface.
+var _ fyne.WidgetRenderer = (*gridWrapListRenderer)(nil)
+
+type gridWrapListRenderer struct {
+	grid    *GridWrapList
+	objects []fyne.CanvasObject
+}
+
+func (r *gridWrapListRenderer) Layout(size fyne.Size) {
+	g := r.grid
+	g.list.Resize(size)
+	if columns := g.columnsForWidth(size.Width); columns != g.columns {
+		g.columns = columns
+		g.list.currentFocus = g.currentFocus / columns
+		g.list.Refresh()
+	}
+}
+
+func (r *gridWrapListRenderer) MinSize() fyne.Size {
+	return r.grid.list.MinSize()
+}
+
+func (r *gridWrapListRenderer) Refresh() {
+	r.grid.updateItemMin()
+	r.grid.drag.refreshStyle(r.grid.DragIndicator)
+	r.grid.list.Refresh()
+}
+
+func (r *gridWrapListRenderer) Destroy() {}
+
+func (r *gridWrapListRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*gridRow)(nil)
+var _ fyne.Tappable = (*gridRow)(nil)
+var _ desktop.Hoverable = (*gridRow)(nil)
+
+// gridRow holds the items of a row of a GridWrapList. It takes the taps and hovers
+// between its items so that the list row behind it is not highlighted.
+type gridRow struct {
+	widget.BaseWidget
+	box *fyne.Container
+}
+
+func newGridRow(grid *GridWrapList) *gridRow {
+	r := &gridRow{box: container.New(&gridRowLayout{grid: grid})}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (r *gridRow) CreateRenderer() fyne.WidgetRenderer {
+	r.ExtendBaseWidget(r)
+	return widget.NewSimpleRenderer(r.box)
+}
+
+// Tapped is called when the space between items is tapped.
+func (r *gridRow) Tapped(*fyne.PointEvent) {
+}
+
+// MouseIn is called when a desktop pointer enters the row.
+func (r *gridRow) MouseIn(*desktop.MouseEvent) {
+}
+
+// MouseMoved is called when a desktop pointer hovers over the row.
+func (r *gridRow) MouseMoved(*desktop.MouseEvent) {
+}
+
+// MouseOut is called when a desktop pointer exits the row.
+func (r *gridRow) MouseOut() {
+}
+
+// gridRowLayout places the items of a grid row side by side, at the item size of the grid.
+type gridRowLayout struct {
+	grid *GridWrapList
+}
+
+func (l *gridRowLayout) Layout(objects []fyne.CanvasObject, _ fyne.Size) {
+	x := float32(0)
+	for _, o := range objects {
+		o.Resize(l.grid.itemMin)
+		o.Move(fyne.NewPos(x, 0))
+		x += l.grid.itemMin.Width + theme.Padding()
+	}
+}
+
+func (l *gridRowLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return l.grid.itemMin
+}
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*gridCell)(nil)
+var _ fyne.Tappable = (*gridCell)(nil)
+var _ desktop.Hoverable = (*gridCell)(nil)
+var _ fyne.Draggable = (*gridCell)(nil)
+
+// gridCell shows an item of a GridWrapList.
+type gridCell struct {
+	widget.BaseWidget
+
+	id                ListItemID
+	grid              *GridWrapList
+	child             fyne.CanvasObject
+	background        *canvas.Rectangle
+	focusRing         *canvas.Rectangle
+	editMark          *fyne.Container // shows whether the item is selected in edit mode
+	editMarkIcon      *widget.Icon
+	hovered, selected bool
+	focused           bool // has the keyboard focus of the grid
+	editing           bool // shows the edit mark
+	scrolling         bool // drag is being forwarded to the scroller
+}
+
+func newGridCell(child fyne.CanvasObject, grid *GridWrapList) *gridCell {
+	c := &gridCell{grid: grid, child: child}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (c *gridCell) CreateRenderer() fyne.WidgetRenderer {
+	c.ExtendBaseWidget(c)
+
+	c.background = canvas.NewRectangle(theme.HoverColor())
+	c.focusRing = canvas.NewRectangle(color.Transparent)
+	c.editMark, c.editMarkIcon = newEditMark()
+	c.updateBackground()
+	marks := container.NewBorder(container.NewHBox(c.editMark), nil, nil, nil)
+	return widget.NewSimpleRenderer(container.NewStack(c.background, c.child, marks, c.focusRing))
+}
+
+// MouseIn is called when a desktop pointer enters the widget.
+func (c *gridCell) MouseIn(*desktop.MouseEvent) {
+	if c.grid.dragging >= 0 {
+		return
+	}
+	c.hovered = true
+	c.Refresh()
+}
+
+// MouseMoved is called when a desktop pointer hovers over the widget.
+func (c *gridCell) MouseMoved(*desktop.MouseEvent) {
+}
+
+// MouseOut is called when a desktop pointer exits the widget.
+func (c *gridCell) MouseOut() {
+	c.hovered = false
+	c.Refresh()
+}
+
+// Tapped is called when a pointer tapped event is captured and selects the item,
+// or adds it to or removes it from the selection in edit mode.
+func (c *gridCell) Tapped(*fyne.PointEvent) {
+	g := c.grid
+	if !fyne.CurrentDevice().IsMobile() {
+		if canvas := fyne.CurrentApp().Driver().CanvasForObject(g.list); canvas != nil {
+			canvas.Focus(g.list)
+		}
+		g.moveFocus(c.id)
+	}
+	if g.editMode {
+		g.toggleSelected(c.id)
+	} else {
+		g.Select(c.id)
+	}
+}
+
+func (c *gridCell) Dragged(e *fyne.DragEvent) {
+	// items capture drags, so pass them to the scroller when they should not reorder
+	g := c.grid
+	if c.scrolling || (g.dragging < 0 && !g.canDragItem(c.id)) {
+		c.scrolling = true
+		g.list.scroller.Dragged(e)
+		return
+	}
+	g.onCellDragged(c, e)
+}
+
+func (c *gridCell) DragEnd() {
+	if c.scrolling {
+		c.scrolling = false
+		c.grid.list.scroller.DragEnd()
+		return
+	}
+	c.grid.onDragEnd()
+}
+
+func (c *gridCell) Refresh() {
+	if c.background != nil { // not rendered yet otherwise
+		c.updateBackground()
+		c.background.Refresh()
+	}
+	canvas.Refresh(c)
+}
+
+// shows the selected, hovered and focused state of the item, and its edit mark
+func (c *gridCell) updateBackground() {
+	c.background.CornerRadius = theme.SelectionRadiusSize()
+	if c.selected {
+		c.background.FillColor = theme.SelectionColor()
+		c.background.Show()
+	} else if c.hovered {
+		c.background.FillColor = theme.HoverColor()
+		c.background.Show()
+	} else {
+		c.background.Hide()
+	}
+	styleFocusRing(c.focusRing, c.focused)
+	if c.editing {
+		setEditMarkIcon(c.editMarkIcon, c.selected)
+		c.editMark.Show()
+	} else {
+		c.editMark.Hide()
+	}
+}
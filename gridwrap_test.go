@@ -0,0 +1,89 @@
+package fyneadvancedlist
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/test"
+)
+
+func newTestGrid(length int) (*GridWrapList, fyne.Window) {
+	grid := NewGridWrapList(
+		func() int { return length },
+		func() fyne.CanvasObject {
+			r := canvas.NewRectangle(nil)
+			r.SetMinSize(fyne.NewSize(40, 40))
+			return r
+		},
+		func(ListItemID, fyne.CanvasObject) {},
+	)
+	w := test.NewWindow(grid)
+	w.Resize(fyne.NewSize(200, 200))
+	return grid, w
+}
+
+func TestGridWrapList_KeyboardFocus(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	grid, w := newTestGrid(20)
+	defer w.Close()
+	w.Canvas().Focus(grid.list)
+
+	columns := grid.columns
+	if columns < 2 {
+		t.Fatalf("grid has %d columns, want several", columns)
+	}
+	for _, tt := range []struct {
+		key  fyne.KeyName
+		want ListItemID
+	}{
+		{fyne.KeyRight, 1},
+		{fyne.KeyDown, 1 + columns},
+		{fyne.KeyLeft, columns},
+		{fyne.KeyUp, 0},
+		{fyne.KeyUp, 0},
+		{fyne.KeyEnd, 19},
+		{fyne.KeyHome, 0},
+	} {
+		grid.list.TypedKey(&fyne.KeyEvent{Name: tt.key})
+		if grid.currentFocus != tt.want {
+			t.Errorf("after %s the focus is on %d, want %d", tt.key, grid.currentFocus, tt.want)
+		}
+		if row := grid.list.currentFocus; row != tt.want/columns {
+			t.Errorf("after %s the list of rows focuses row %d, want %d", tt.key, row, tt.want/columns)
+		}
+	}
+
+	grid.list.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	grid.list.TypedKey(&fyne.KeyEvent{Name: fyne.KeySpace})
+	if len(grid.selected) != 1 || grid.selected[0] != 1 {
+		t.Errorf("selected %v after Space, want [1]", grid.selected)
+	}
+}
+
+func TestGridWrapList_EditModeSelectsSeveral(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	grid, w := newTestGrid(20)
+	defer w.Close()
+
+	grid.Select(1)
+	grid.Select(2)
+	if len(grid.selected) != 1 {
+		t.Errorf("selected %v outside of edit mode, want one item", grid.selected)
+	}
+
+	grid.SetEditMode(true)
+	grid.Select(4)
+	grid.toggleSelected(5)
+	grid.toggleSelected(2)
+	if len(grid.selected) != 2 || indexOfItem(grid.selected, 4) < 0 || indexOfItem(grid.selected, 5) < 0 {
+		t.Errorf("selected %v in edit mode, want 4 and 5", grid.selected)
+	}
+
+	grid.SetEditMode(false)
+	if len(grid.selected) != 0 {
+		t.Errorf("selected %v after leaving edit mode, want none", grid.selected)
+	}
+}
@@ -0,0 +1,81 @@
+package fyneadvancedlist
+
+// heightIndex is a Fenwick tree of padded row heights. It supports updating the
+// height of a row, finding the offset of a row and finding the row at an offset
+// in O(log n), so that lists with variable item heights don't need to sum every row
+// each time they are scrolled.
+type heightIndex struct {
+	tree []float64 // 1-based
+	n    int
+
+	// the inputs the index was built from, to detect when it must be rebuilt
+	valid          bool
+	templateHeight float32
+	padding        float32
+}
+
+// build (re)creates the index for n rows using the given row height function.
+func (h *heightIndex) build(n int, padding float32, height func(ListItemID) float32) {
+	if cap(h.tree) >= n+1 {
+		h.tree = h.tree[:n+1]
+	} else {
+		h.tree = make([]float64, n+1)
+	}
+	h.n = n
+	h.tree[0] = 0
+	for i := 1; i <= n; i++ {
+		h.tree[i] = float64(height(i-1) + padding)
+	}
+	// O(n) construction: push each node's sum to its parent
+	for i := 1; i <= n; i++ {
+		if parent := i + (i & -i); parent <= n {
+			h.tree[parent] += h.tree[i]
+		}
+	}
+	h.padding = padding
+	h.valid = true
+}
+
+// add changes the height of row id by delta.
+func (h *heightIndex) add(id ListItemID, delta float32) {
+	for i := id + 1; i <= h.n; i += i & -i {
+		h.tree[i] += float64(delta)
+	}
+}
+
+// offset returns the Y position of the top of row id,
+// which is the sum of the padded heights of all rows before it.
+// It is a float64, as float32 positions are whole rows out in lists with millions of items.
+func (h *heightIndex) offset(id ListItemID) float64 {
+	if id > h.n {
+		id = h.n
+	}
+	sum := float64(0)
+	for i := id; i > 0; i -= i & -i {
+		sum += h.tree[i]
+	}
+	return sum
+}
+
+// rowAt returns the row that contains the Y position y, clamped to the valid rows.
+func (h *heightIndex) rowAt(y float64) ListItemID {
+	if h.n == 0 {
+		return 0
+	}
+	pos := 0
+	remaining := y
+	step := 1
+	for step*2 <= h.n {
+		step *= 2
+	}
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next <= h.n && h.tree[next] <= remaining {
+			pos = next
+			remaining -= h.tree[next]
+		}
+	}
+	if pos >= h.n {
+		pos = h.n - 1
+	}
+	return pos
+}
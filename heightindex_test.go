@@ -0,0 +1,81 @@
+package fyneadvancedlist
+
+import "testing"
+
+// returns the offset of the top of each row and of the end of the last one
+func naiveOffsets(heights []float32, padding float32) []float64 {
+	offsets := make([]float64, len(heights)+1)
+	for i, h := range heights {
+		offsets[i+1] = offsets[i] + float64(h+padding)
+	}
+	return offsets
+}
+
+// returns the row containing y, clamped to the rows
+func naiveRowAt(offsets []float64, y float64) ListItemID {
+	row := 0
+	for row < len(offsets)-2 && offsets[row+1] <= y {
+		row++
+	}
+	return row
+}
+
+func checkHeightIndex(t *testing.T, h *heightIndex, heights []float32, padding float32) {
+	t.Helper()
+	offsets := naiveOffsets(heights, padding)
+	for id := 0; id <= len(heights)+2; id++ {
+		want := offsets[len(offsets)-1]
+		if id < len(offsets) {
+			want = offsets[id]
+		}
+		if got := h.offset(id); got != want {
+			t.Errorf("offset(%d) = %v, want %v", id, got, want)
+		}
+	}
+	end := offsets[len(offsets)-1]
+	ys := []float64{-10, 0, end, end + 100}
+	for _, o := range offsets {
+		ys = append(ys, o-0.5, o, o+0.5)
+	}
+	for _, y := range ys {
+		if got, want := h.rowAt(y), naiveRowAt(offsets, y); got != want {
+			t.Errorf("rowAt(%v) = %d, want %d", y, got, want)
+		}
+	}
+}
+
+func TestHeightIndex(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		heights []float32
+		padding float32
+	}{
+		{name: "empty"},
+		{name: "one row", heights: []float32{10}, padding: 2},
+		{name: "power of two", heights: []float32{10, 20, 30, 40}, padding: 1},
+		{name: "not a power of two", heights: []float32{5, 15, 25, 10, 10, 30, 7}, padding: 4},
+		{name: "no padding", heights: []float32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			heights := append([]float32(nil), tt.heights...)
+			var h heightIndex
+			h.build(len(heights), tt.padding, func(id ListItemID) float32 { return heights[id] })
+			checkHeightIndex(t, &h, heights, tt.padding)
+
+			for id := range heights {
+				delta := float32(id%3+1) * 3
+				heights[id] += delta
+				h.add(id, delta)
+				checkHeightIndex(t, &h, heights, tt.padding)
+			}
+		})
+	}
+}
+
+func TestHeightIndex_Rebuild(t *testing.T) {
+	var h heightIndex
+	h.build(9, 0, func(ListItemID) float32 { return 50 })
+	heights := []float32{10, 20, 30}
+	h.build(len(heights), 1, func(id ListItemID) float32 { return heights[id] })
+	checkHeightIndex(t, &h, heights, 1)
+}
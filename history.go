@@ -0,0 +1,107 @@
+package fyneadvancedlist
+
+// ReorderHistory records the rows moved by dragging in a list, so that the moves
+// can be undone and redone, such as after an accidental drop.
+//
+// Since: Not a core Fyne list API
+type ReorderHistory struct {
+	// Limit is the number of moves that can be undone, or 0 for no limit.
+	Limit int
+
+	list       *List
+	undo, redo []reorderMove
+}
+
+type reorderMove struct {
+	from, to ListItemID
+}
+
+// NewReorderHistory creates a history which records the rows dropped in the list from now on.
+// Undone and redone moves are replayed like drops: through the adapter of a list created with
+// NewReorderableList, and then OnDragEnd.
+//
+// Since: Not a core Fyne list API
+func NewReorderHistory(list *List) *ReorderHistory {
+	h := &ReorderHistory{list: list}
+	list.reorderHistory = h
+	return h
+}
+
+// CanUndo returns whether there is a move to undo.
+//
+// Since: Not a core Fyne list API
+func (h *ReorderHistory) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+// CanRedo returns whether there is an undone move to redo.
+//
+// Since: Not a core Fyne list API
+func (h *ReorderHistory) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+// Undo moves the row that was last moved back to where it was.
+// It returns false if there is nothing to undo.
+//
+// Since: Not a core Fyne list API
+func (h *ReorderHistory) Undo() bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+	m := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, m)
+	h.list.replayMove(m.to, m.from)
+	return true
+}
+
+// Redo moves the row of the move that was last undone again.
+// It returns false if there is nothing to redo.
+//
+// Since: Not a core Fyne list API
+func (h *ReorderHistory) Redo() bool {
+	if len(h.redo) == 0 {
+		return false
+	}
+	m := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, m)
+	h.list.replayMove(m.from, m.to)
+	return true
+}
+
+// Clear forgets all recorded moves, such as after the list data is reloaded.
+//
+// Since: Not a core Fyne list API
+func (h *ReorderHistory) Clear() {
+	h.undo, h.redo = nil, nil
+}
+
+func (h *ReorderHistory) record(from, to ListItemID) {
+	if from == to {
+		return
+	}
+	h.redo = nil
+	h.undo = append(h.undo, reorderMove{from: from, to: to})
+	if h.Limit > 0 && len(h.undo) > h.Limit {
+		h.undo = h.undo[len(h.undo)-h.Limit:]
+	}
+}
+
+// moves the item at from to the index to, as if it had been dragged there
+func (l *List) replayMove(from, to ListItemID) {
+	insertAt := to
+	if to > from {
+		insertAt = to + 1
+	}
+	if l.reorderAdapter != nil {
+		l.moveItem(from, insertAt)
+	}
+	if f := l.OnDragEnd; f != nil {
+		f(from, insertAt)
+		if l.reorderAdapter == nil {
+			l.Refresh()
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package fyneadvancedlist
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ShowIndexBar shows a strip of labels, such as the letters of the alphabet, on the trailing
+// edge of the list. Tapping or dragging over a label scrolls to the first item whose key, as
+// returned by key, is that label, or to the first item of a later label if there is none.
+// If labels is nil, the letters A to Z are used.
+//
+// Since: Not a core Fyne list API
+func (l *List) ShowIndexBar(labels []string, key func(id ListItemID) string) {
+	if labels == nil {
+		for c := 'A'; c <= 'Z'; c++ {
+			labels = append(labels, string(c))
+		}
+	}
+	l.propertyLock.Lock()
+	l.indexLabels = labels
+	l.indexTargets = nil
+	l.propertyLock.Unlock()
+	l.indexKey = key
+	l.Refresh()
+}
+
+// HideIndexBar removes the strip of labels shown by ShowIndexBar.
+//
+// Since: Not a core Fyne list API
+func (l *List) HideIndexBar() {
+	l.propertyLock.Lock()
+	l.indexLabels = nil
+	l.indexTargets = nil
+	l.propertyLock.Unlock()
+	l.indexKey = nil
+	l.Refresh()
+}
+
+// scrolls so that the first item with the index label at i, or a later label, is at the top
+func (l *List) scrollToIndexLabel(i int) {
+	key := l.indexKey
+	if key == nil || i < 0 || i >= len(l.indexLabels) {
+		return
+	}
+	targets := l.indexLabelTargets(key)
+	if i >= len(targets) {
+		return // the labels changed meanwhile
+	}
+	if target := targets[i]; target >= 0 && l.scroller != nil {
+		l.scrollToAligned(target, ScrollAlignTop)
+		l.scroller.Refresh() // move the content to the new scroll offset
+	}
+}
+
+// returns, for each index label, the first item with that label or else a later one, or -1 if there
+// is none. They are worked out once, calling key for every item, and kept until the list is refreshed.
+func (l *List) indexLabelTargets(key func(ListItemID) string) []ListItemID {
+	length := l.length()
+	l.propertyLock.RLock()
+	targets, labels := l.indexTargets, l.indexLabels
+	valid := targets != nil && l.indexTargetsLen == length
+	l.propertyLock.RUnlock()
+	if valid {
+		return targets
+	}
+
+	first := make(map[string]int, len(labels))
+	for i := len(labels) - 1; i >= 0; i-- {
+		first[labels[i]] = i
+	}
+	targets = make([]ListItemID, len(labels))
+	for i := range targets {
+		targets[i] = -1
+	}
+	for id := 0; id < length; id++ {
+		if i, ok := first[key(id)]; ok && targets[i] < 0 {
+			targets[i] = id
+		}
+	}
+	for i := len(targets) - 2; i >= 0; i-- {
+		if targets[i] < 0 {
+			targets[i] = targets[i+1]
+		}
+	}
+
+	l.propertyLock.Lock()
+	l.indexTargets, l.indexTargetsLen = targets, length
+	l.propertyLock.Unlock()
+	return targets
+}
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*indexBar)(nil)
+var _ fyne.Tappable = (*indexBar)(nil)
+var _ fyne.Draggable = (*indexBar)(nil)
+
+// indexBar is the strip of labels shown by ShowIndexBar.
+type indexBar struct {
+	widget.BaseWidget
+	list *List
+}
+
+func newIndexBar(list *List) *indexBar {
+	b := &indexBar{list: list}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (b *indexBar) CreateRenderer() fyne.WidgetRenderer {
+	b.ExtendBaseWidget(b)
+	r := &indexBarRenderer{bar: b}
+	r.Refresh()
+	return r
+}
+
+// Tapped is called when a pointer tapped event is captured and scrolls to the tapped label.
+func (b *indexBar) Tapped(e *fyne.PointEvent) {
+	b.list.scrollToIndexLabel(b.labelAt(b.list.orientPos(e.Position).Y))
+}
+
+// Dragged is called when the pointer is dragged over the bar and scrolls to the label under it.
+func (b *indexBar) Dragged(e *fyne.DragEvent) {
+	b.list.scrollToIndexLabel(b.labelAt(b.list.orientPos(e.Position).Y))
+}
+
+// DragEnd is called when a drag over the bar ends.
+func (b *indexBar) DragEnd() {
+}
+
+// returns the index of the label at the Y position within the bar, in the frame of the list
+func (b *indexBar) labelAt(y float32) int {
+	n := len(b.list.indexLabels)
+	if n == 0 {
+		return -1
+	}
+	top, slot := indexBarSlots(n, b.list.orient(b.Size()).Height)
+	i := int((y - top) / slot)
+	if i < 0 {
+		i = 0
+	} else if i >= n {
+		i = n - 1
+	}
+	return i
+}
+
+// returns the Y position of the first label and the height of each label
+// when n labels are spread over the height of the bar
+func indexBarSlots(n int, height float32) (top, slot float32) {
+	slot = theme.CaptionTextSize() + theme.Padding()
+	if n > 0 && slot*float32(n) > height {
+		slot = height / float32(n)
+	}
+	return (height - slot*float32(n)) / 2, slot
+}
+
+type indexBarRenderer struct {
+	bar   *indexBar
+	texts []*canvas.Text
+	objs  []fyne.CanvasObject
+}
+
+func (r *indexBarRenderer) Layout(size fyne.Size) {
+	orient, orientPos := r.bar.list.orient, r.bar.list.orientPos
+	size = orient(size)
+	top, slot := indexBarSlots(len(r.texts), size.Height)
+	for i, t := range r.texts {
+		t.Resize(orient(fyne.NewSize(size.Width, slot)))
+		t.Move(orientPos(fyne.NewPos(0, top+float32(i)*slot)))
+	}
+}
+
+func (r *indexBarRenderer) MinSize() fyne.Size {
+	width := float32(0)
+	for _, t := range r.texts {
+		width = fyne.Max(width, r.bar.list.orient(t.MinSize()).Width)
+	}
+	return r.bar.list.orient(fyne.NewSize(width+2*theme.Padding(), 0))
+}
+
+func (r *indexBarRenderer) Refresh() {
+	labels := r.bar.list.indexLabels
+	for len(r.texts) < len(labels) {
+		t := canvas.NewText("", theme.ForegroundColor())
+		t.Alignment = fyne.TextAlignCenter
+		r.texts = append(r.texts, t)
+	}
+	r.texts = r.texts[:len(labels)]
+	r.objs = r.objs[:0]
+	for i, t := range r.texts {
+		t.Text = labels[i]
+		t.Color = theme.PrimaryColor()
+		t.TextSize = theme.CaptionTextSize()
+		t.TextStyle.Bold = true
+		t.Refresh()
+		r.objs = append(r.objs, t)
+	}
+	r.Layout(r.bar.Size())
+}
+
+func (r *indexBarRenderer) Objects() []fyne.CanvasObject {
+	return r.objs
+}
+
+func (r *indexBarRenderer) Destroy() {
+}
@@ -0,0 +1,48 @@
+package fyneadvancedlist
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestList_IndexBarKeysWorkedOutOnce(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	keys := []string{"A", "A", "C", "C", "C", "E", "F", "F"}
+	list := NewList(
+		func() int { return len(keys) },
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(ListItemID, fyne.CanvasObject) {},
+	)
+	calls := 0
+	list.ShowIndexBar([]string{"A", "B", "C", "D", "E", "F", "G"}, func(id ListItemID) string {
+		calls++
+		return keys[id]
+	})
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 100))
+
+	want := []ListItemID{0, 2, 2, 5, 5, 6, -1}
+	for i := range want {
+		list.scrollToIndexLabel(i)
+	}
+	targets := list.indexLabelTargets(list.indexKey)
+	for i, id := range want {
+		if targets[i] != id {
+			t.Errorf("label %d scrolls to item %d, want %d", i, targets[i], id)
+		}
+	}
+	if calls != len(keys) {
+		t.Errorf("key called %d times, want once for each of the %d items", calls, len(keys))
+	}
+
+	keys = append(keys, "G")
+	list.Refresh()
+	if got := list.indexLabelTargets(list.indexKey)[6]; got != 8 {
+		t.Errorf("label G scrolls to item %d after a refresh, want 8", got)
+	}
+}
@@ -2,15 +2,20 @@ package fyneadvancedlist
 
 import (
 	"fmt"
+	"image/color"
 	"math"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/driver/mobile"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
@@ -21,9 +26,13 @@ type ListItemID = int
 // Declare conformity with interfaces.
 var _ fyne.Widget = (*List)(nil)
 var _ fyne.Focusable = (*List)(nil)
+var _ fyne.Tabbable = (*List)(nil)
+var _ desktop.Keyable = (*List)(nil)
+var _ fyne.Shortcutable = (*List)(nil)
 
 // List is a widget that pools list items for performance and
-// lays the items out in a vertical direction inside of a scroller.
+// lays the items out in a vertical direction inside of a scroller,
+// or a horizontal direction if created with NewHorizontalList.
 // By default, List requires that all items are the same size, but specific
 // rows can have their heights set with SetItemHeight.
 //
@@ -38,26 +47,483 @@ type List struct {
 	OnSelected   func(id ListItemID)                         `json:"-"`
 	OnUnselected func(id ListItemID)                         `json:"-"`
 
+	// UpdateItemAsync, if set, is used instead of UpdateItem for items whose data loads
+	// slowly. It should show placeholder content in item, start loading and return.
+	// Once loaded, call done, from any goroutine, with a function that shows the data in item.
+	// If the row has been reused for another item in the meantime, the function is not called.
+	//
+	// Since: Not a core Fyne list API
+	UpdateItemAsync func(id ListItemID, item fyne.CanvasObject, done func(apply func())) `json:"-"`
+
+	// UpdateItemWith, if set, is used instead of UpdateItem and is also passed the value set
+	// with SetContext, such as the theme or the item being played, so that rows can show
+	// state shared by the whole list without the callback capturing it.
+	//
+	// Since: Not a core Fyne list API
+	UpdateItemWith func(id ListItemID, item fyne.CanvasObject, ctx any) `json:"-"`
+
+	// CreateLeadingAccessory and CreateTrailingAccessory, if set, create objects the list places
+	// before and after the content of every row, at their minimum width, such as an icon, check box
+	// or drag grip before it and action buttons after it, so that the item template stays simple.
+	// UpdateLeadingAccessory and UpdateTrailingAccessory bind them to the item shown by the row.
+	// Accessories that handle taps themselves, such as buttons, don't select the row when tapped.
+	// Hidden accessories take no space.
+	//
+	// Since: Not a core Fyne list API
+	CreateLeadingAccessory  func() fyne.CanvasObject                         `json:"-"`
+	UpdateLeadingAccessory  func(id ListItemID, accessory fyne.CanvasObject) `json:"-"`
+	CreateTrailingAccessory func() fyne.CanvasObject                         `json:"-"`
+	UpdateTrailingAccessory func(id ListItemID, accessory fyne.CanvasObject) `json:"-"`
+
 	// HideSeparators hides the separators between list rows
 	//
 	// Since: 2.5
 	HideSeparators bool
 
-	// Enable drag-and-drop of rows within the list
+	// RowPadding is the space between rows, and around the header and footer, in place of
+	// the theme padding, such as for a compact or airy list. Set it to a negative value
+	// for no space. Call Refresh after changing it while the list is visible.
+	//
+	// Since: Not a core Fyne list API
+	RowPadding float32
+
+	// ItemHeight, if set, returns the height of each row instead of the template height.
+	// It is an alternative to SetItemHeight for lists whose heights can be computed,
+	// although heights set with SetItemHeight take precedence.
+	// Call Refresh if the heights it returns change. It is called while the list
+	// computes its row layout, holding an internal lock, so it must not call
+	// methods of the list.
+	//
+	// Since: Not a core Fyne list API
+	ItemHeight func(id ListItemID) float32 `json:"-"`
+
+	// HeightForWidth, if set, returns the height of each row when the list is the given width,
+	// for rows whose content wraps. The row heights and content size are recomputed
+	// when the list is resized horizontally. It takes precedence over ItemHeight.
+	// Like ItemHeight, it must not call methods of the list.
+	//
+	// Since: Not a core Fyne list API
+	HeightForWidth func(id ListItemID, width float32) float32 `json:"-"`
+
+	// ItemHeightBinding, if set on a list created with NewListWithData, returns a binding that
+	// holds the height of the row showing the data item, or nil for the default height.
+	// Rows follow their bindings as they change while they are shown, as if SetItemHeight
+	// had been called, although heights set with SetItemHeight take precedence. Items
+	// that haven't been shown yet have the default height.
+	//
+	// Since: Not a core Fyne list API
+	ItemHeightBinding func(id ListItemID, item binding.DataItem) binding.Float `json:"-"`
+
+	// OnCheckChanged is called when the check state of an item is changed with SetItemCheckState.
+	//
+	// Since: Not a core Fyne list API
+	OnCheckChanged func(id ListItemID, state CheckState) `json:"-"`
+
+	// OverscanRows is the number of extra rows above and below the visible area that
+	// are created and updated ahead of time, for smoother scrolling with heavy rows.
+	//
+	// Since: Not a core Fyne list API
+	OverscanRows int
+
+	// MaxPooledItems is the most rows that are kept for reuse while not shown, such as
+	// after the window is made smaller. Rows past the limit are dropped to free their memory.
+	// If 0 there is no limit. See also ReleasePooledItems.
+	//
+	// Since: Not a core Fyne list API
+	MaxPooledItems int
+
+	// SnapToRows makes the list scroll to the nearest boundary between rows
+	// once the user stops scrolling, so that the top row is never cut off.
+	//
+	// Since: Not a core Fyne list API
+	SnapToRows bool
+
+	// OnScrolled is called with the new scroll offset whenever the list scrolls.
+	//
+	// Since: Not a core Fyne list API
+	OnScrolled func(offset float32) `json:"-"`
+
+	// OnReachedEnd is called when the list is scrolled to within ReachedEndRows rows plus
+	// ReachedEndDistance of the bottom, such as to load the next page of an endless feed.
+	// It is called again once the list has grown or has been scrolled away from the end.
+	// If both distances are zero, it is called within one row of the bottom.
+	//
+	// Since: Not a core Fyne list API
+	OnReachedEnd       func() `json:"-"`
+	ReachedEndRows     int
+	ReachedEndDistance float32
+
+	// PageSize is the number of items requested at a time by SetPageProvider.
+	// If it is zero, DefaultPageSize is used.
+	//
+	// Since: Not a core Fyne list API
+	PageSize int
+
+	// LengthEstimate, if set, returns how many items the list is expected to have once all of
+	// its data has arrived, and whether that number is exact, while Length returns how many
+	// have arrived so far. The list can be scrolled over the expected range, showing placeholder
+	// rows for the items still to arrive, and calls OnFrontierReached when they are scrolled
+	// into view so that more can be fetched. While the estimate is not exact, or is negative
+	// for an unbounded stream, one placeholder row is shown after the items that have arrived.
+	//
+	// Since: Not a core Fyne list API
+	LengthEstimate    func() (n int, exact bool) `json:"-"`
+	OnFrontierReached func(arrived int)          `json:"-"`
+
+	// IsSectionStart, if set, divides the list into sections by returning whether an item
+	// is the first of a section. A header created by CreateSectionHeader and updated by
+	// UpdateSectionHeader with the ID of that item is shown above it. IsSectionStart is
+	// called while the list computes its row layout, holding an internal lock, so it
+	// must not call methods of the list.
+	//
+	// Since: Not a core Fyne list API
+	IsSectionStart      func(id ListItemID) bool                      `json:"-"`
+	CreateSectionHeader func() fyne.CanvasObject                      `json:"-"`
+	UpdateSectionHeader func(id ListItemID, header fyne.CanvasObject) `json:"-"`
+
+	// CollapsibleSections lets sections be collapsed and expanded by tapping their headers.
+	// Only the header of a collapsed section is shown.
+	//
+	// Since: Not a core Fyne list API
+	CollapsibleSections bool
+	OnSectionToggled    func(id ListItemID, collapsed bool) `json:"-"`
+
+	// ReorderWithinSections only lets rows be dropped in the section they were dragged from.
+	// While the pointer is over another section, no drop position is shown and the row
+	// is dropped at the nearest end of its own section.
+	//
+	// Since: Not a core Fyne list API
+	ReorderWithinSections bool
+
+	// TypeAheadText, if set, returns the text of an item for type-ahead search: characters
+	// typed in quick succession while the list is focused move the focus to the next item
+	// whose text starts with them, ignoring case.
+	//
+	// Since: Not a core Fyne list API
+	TypeAheadText func(id ListItemID) string `json:"-"`
+
+	// OnItemSecondaryTapped is called when a row is right-clicked, or long pressed on touch devices.
+	// If MenuForItem is set and returns a menu for the item, the menu is shown as a popup
+	// at the tapped position.
+	//
+	// Since: Not a core Fyne list API
+	OnItemSecondaryTapped func(id ListItemID, e *fyne.PointEvent) `json:"-"`
+	MenuForItem           func(id ListItemID) *fyne.Menu          `json:"-"`
+
+	// OnItemDoubleTapped is called when a row is tapped twice in quick succession.
+	// The first tap still selects the row straight away. See also OnItemActivated,
+	// which is also called for Enter.
+	//
+	// Since: Not a core Fyne list API
+	OnItemDoubleTapped func(id ListItemID) `json:"-"`
+
+	// OnItemActivated is called when a row is activated, such as to open it: when it is
+	// double tapped, or tapped once if ActivateOnSingleTap is set, or when Enter is pressed
+	// while the list is focused. Tapping a row still selects it.
+	//
+	// Since: Not a core Fyne list API
+	OnItemActivated     func(id ListItemID) `json:"-"`
+	ActivateOnSingleTap bool
+
+	// OnItemLongPressed is called when a row is touched, or held with the primary mouse button,
+	// for half a second without moving, such as to enter an editing mode. pos is the absolute
+	// position of the press in the canvas. A long press that lifts a row for reordering
+	// also calls it, and on touch devices releasing a long press also taps the row secondarily.
+	//
+	// Since: Not a core Fyne list API
+	OnItemLongPressed func(id ListItemID, pos fyne.Position) `json:"-"`
+
+	// ItemEnabled returns whether the item can be interacted with. Disabled rows are dimmed,
+	// skipped when moving the keyboard focus, and are not highlighted, selected or tapped.
+	//
+	// Since: Not a core Fyne list API
+	ItemEnabled func(id ListItemID) bool `json:"-"`
+
+	// CursorForItem returns the desktop cursor shown when the pointer is over a row,
+	// such as desktop.PointerCursor for rows that act as links.
+	//
+	// Since: Not a core Fyne list API
+	CursorForItem func(id ListItemID) desktop.Cursor `json:"-"`
+
+	// OnItemKeyTyped is called with the focused item when a key is typed while the list is
+	// focused, before the list handles it, such as to rename the item when F2 is typed.
+	// Returning true stops the list from handling the key.
+	//
+	// Since: Not a core Fyne list API
+	OnItemKeyTyped func(id ListItemID, ev *fyne.KeyEvent) bool `json:"-"`
+
+	// OnDeleteRequested is called with the selected items when Delete or Backspace is typed
+	// while the list is focused and has a selection. The list does not remove them itself.
+	//
+	// Since: Not a core Fyne list API
+	OnDeleteRequested func(ids []ListItemID) `json:"-"`
+
+	// AccessibilityLabelForItem returns the text assistive technology announces for a row,
+	// see AccessibilityInfo.
+	//
+	// Since: Not a core Fyne list API
+	AccessibilityLabelForItem func(id ListItemID) string `json:"-"`
+
+	// ItemKey returns a key which identifies the item across data reloads and sessions,
+	// such as a database ID, for SaveState and RestoreState.
+	//
+	// Since: Not a core Fyne list API
+	ItemKey func(id ListItemID) string `json:"-"`
+
+	// OnCopy, OnCut and OnPaste are called when the copy, cut or paste shortcut is typed
+	// while the list is focused, with the selected items, or the focused item if none
+	// are selected, and the clipboard to write to or read from.
+	//
+	// Since: Not a core Fyne list API
+	OnCopy  func(ids []ListItemID, clipboard fyne.Clipboard) `json:"-"`
+	OnCut   func(ids []ListItemID, clipboard fyne.Clipboard) `json:"-"`
+	OnPaste func(ids []ListItemID, clipboard fyne.Clipboard) `json:"-"`
+
+	// CreateEditItem returns a template, such as an entry, shown in place of the content of a row
+	// while it is edited after BeginEdit. CommitEdit is called with it when Enter is typed,
+	// and CancelEdit when Escape is typed. The list keeps the keyboard focus while editing,
+	// and passes other keys on to the focusable widgets of the template as with FocusRowContent.
+	//
+	// Since: Not a core Fyne list API
+	CreateEditItem func(id ListItemID) fyne.CanvasObject         `json:"-"`
+	CommitEdit     func(id ListItemID, editor fyne.CanvasObject) `json:"-"`
+	CancelEdit     func(id ListItemID, editor fyne.CanvasObject) `json:"-"`
+
+	// OnItemHovered is called when a desktop pointer enters or leaves a row.
+	//
+	// Since: Not a core Fyne list API
+	OnItemHovered func(id ListItemID, entered bool) `json:"-"`
+
+	// SelectOnHoverDelay, if not zero, selects a row once a desktop pointer has rested on it
+	// for this long, such as for a preview pane that follows the pointer. The list is not
+	// scrolled to the row and the keyboard focus stays where it is. It has no effect in edit mode.
+	//
+	// Since: Not a core Fyne list API
+	SelectOnHoverDelay time.Duration
+
+	// TooltipForItem returns the text of a tooltip shown when the pointer rests on a row,
+	// or "" for no tooltip.
+	//
+	// Since: Not a core Fyne list API
+	TooltipForItem func(id ListItemID) string `json:"-"`
+
+	// LeadingSwipeActions and TrailingSwipeActions return the actions revealed behind a row
+	// when it is swiped right or left, or down and up in a horizontal list. A swipe past
+	// half of the actions snaps the row open, and tapping a row or scrolling closes it.
+	// A drag that begins across the list swipes the row rather than reordering it.
+	//
+	// Since: Not a core Fyne list API
+	LeadingSwipeActions  func(id ListItemID) []SwipeAction `json:"-"`
+	TrailingSwipeActions func(id ListItemID) []SwipeAction `json:"-"`
+
+	// AlternateRows draws a background behind every other row, beneath the hover and
+	// selection highlights, to make wide rows easier to follow. AlternateRowColor sets
+	// its color, which is a faint hover color if nil.
+	// Call Refresh after changing them while the list is visible.
+	//
+	// Since: Not a core Fyne list API
+	AlternateRows     bool
+	AlternateRowColor color.Color
+
+	// BackgroundForItem returns the color drawn behind a row beneath the hover and selection
+	// highlights, such as to tint rows with errors, or nil for the default background.
+	//
+	// Since: Not a core Fyne list API
+	BackgroundForItem func(id ListItemID) color.Color `json:"-"`
+
+	// AutoSizeItems makes each row as tall as the MinSize of its content after UpdateItem.
+	// Measured heights are cached, and heights set with SetItemHeight, HeightForWidth
+	// or ItemHeight take precedence.
+	//
+	// Since: Not a core Fyne list API
+	AutoSizeItems bool
+
+	// ScrollWideRows lets rows be wider than the list, such as the lines of a log viewer,
+	// instead of fitting them to its width. The rows are as wide as the widest content shown
+	// so far, and the list scrolls across them as well as along.
+	//
+	// Since: Not a core Fyne list API
+	ScrollWideRows bool
+
+	// Enable drag-and-drop of rows within the list. The focused row can also be moved
+	// with Ctrl or Alt and the arrow keys, which calls OnDragEnd like a drop.
 	//
 	// Not core Fyne APIs
 	EnableDragging bool
 	OnDragEnd      func(draggedFrom, draggedTo ListItemID) `json:"-"`
 	OnDragBegin    func(id ListItemID)                     `json:"-"`
 
-	currentFocus  ListItemID
-	focused       bool
-	scroller      *container.Scroll
-	selected      []ListItemID
-	itemMin       fyne.Size
-	itemHeights   map[ListItemID]float32
-	offsetY       float32
-	offsetUpdated func(fyne.Position)
+	// OnDragFeedback is called when a row is picked up, when the position it would be dropped at
+	// changes and when it is dropped, such as to trigger haptics on mobile or play a sound.
+	//
+	// Since: Not a core Fyne list API
+	OnDragFeedback func(kind DragFeedbackKind) `json:"-"`
+
+	// ShowReorderGrips shows a grip at the trailing end of each row that can be dragged,
+	// as in edit mode, see SetEditMode. Rows are then only dragged by their grip,
+	// and drags elsewhere scroll the list.
+	//
+	// Since: Not a core Fyne list API
+	ShowReorderGrips bool
+
+	// OnURIsDropped is called when URIs are dropped onto the list from the desktop.
+	// See Dropped for how to deliver drops from the window to the list.
+	//
+	// Since: Not a core Fyne list API
+	OnURIsDropped func(insertAt ListItemID, uris []fyne.URI) `json:"-"`
+
+	// DragDataForItem returns the payload delivered to a DropTarget
+	// when a row is dragged out of the list and dropped onto it.
+	//
+	// Since: Not a core Fyne list API
+	DragDataForItem func(id ListItemID) any `json:"-"`
+
+	// OnDragRemoved is called instead of OnDragEnd when a row is dropped onto a target
+	// added with AddDragRemoveTarget, such as a trash icon, for the app to delete the item.
+	//
+	// Since: Not a core Fyne list API
+	OnDragRemoved func(id ListItemID) `json:"-"`
+
+	// AnimateReorder slides rows into their new positions after a drop.
+	// The list assumes that the data was moved as reported to OnDragEnd.
+	//
+	// Since: Not a core Fyne list API
+	AnimateReorder bool
+
+	// AnimateChanges animates the rows inserted, removed and moved by ApplyDiff,
+	// including changes made through a ListModel. Inserted rows expand from no height and
+	// removed rows collapse, while the other rows slide into their new positions.
+	//
+	// Since: Not a core Fyne list API
+	AnimateChanges bool
+
+	// CanDragItem, if set, is called before a drag begins on a row
+	// and prevents the row from being reordered if it returns false.
+	//
+	// Since: Not a core Fyne list API
+	CanDragItem func(id ListItemID) bool `json:"-"`
+
+	// ItemLocked, if set, returns whether an item is locked in place, such as a header row
+	// at the top. Locked rows cannot be dragged, and rows can only be dropped where moving
+	// them would not displace a locked row.
+	//
+	// Since: Not a core Fyne list API
+	ItemLocked func(id ListItemID) bool `json:"-"`
+
+	// DragStartThreshold is the distance the pointer must move
+	// after pressing on a row before a reorder drag begins.
+	//
+	// Since: Not a core Fyne list API
+	DragStartThreshold float32
+
+	// LongPressToDrag makes reordering on touch devices begin only after a row
+	// has been long pressed, so that shorter drags scroll the list instead.
+	//
+	// Since: Not a core Fyne list API
+	LongPressToDrag bool
+
+	// TouchDrag tunes how drags on rows are told apart on touch devices, such as to let
+	// swipes along the list scroll it while rows that are held and then moved are reordered.
+	//
+	// Since: Not a core Fyne list API
+	TouchDrag TouchDragConfig
+
+	// DragScroll tunes the auto-scrolling when a row is dragged near the list edges
+	//
+	// Since: Not a core Fyne list API
+	DragScroll DragScrollConfig
+	// ScrollBar customizes the scroll bar, such as to keep it always visible.
+	// Call Refresh after changing it while the list is visible.
+	//
+	// Since: Not a core Fyne list API
+	ScrollBar ScrollBarStyle
+	// DragIndicator customizes the insertion indicator shown while dragging.
+	// Call Refresh after changing it while the list is visible.
+	//
+	// Since: Not a core Fyne list API
+	DragIndicator DragIndicatorStyle
+	// Separators customizes the separators between rows.
+	// Call Refresh after changing it while the list is visible.
+	//
+	// Since: Not a core Fyne list API
+	Separators SeparatorStyle
+	// FocusRowContent lets Tab and Enter move the keyboard focus into the focusable widgets,
+	// such as entries and buttons, in the containers of the focused row. Tab and Shift+Tab
+	// move between them and Escape returns the focus to the list.
+	//
+	// Since: Not a core Fyne list API
+	FocusRowContent bool
+
+	dropTargets       []DropTarget
+	currentFocus      ListItemID
+	focused           bool
+	rowFocus          fyne.Focusable // widget focused within the focused row, see FocusRowContent
+	shiftDown         bool
+	editor            fyne.CanvasObject // shown in the row of editingID, see BeginEdit
+	editingID         ListItemID
+	reorderAdapter    ReorderableAdapter
+	reorderHistory    *ReorderHistory // set by NewReorderHistory
+	horizontal        bool            // set by NewHorizontalList
+	scroller          *container.Scroll
+	selected          []ListItemID
+	editMode          bool       // several items can be selected, see SetEditMode
+	itemMin           fyne.Size  // the template size in the frame of the list, see orient
+	headerMin         fyne.Size  // the section header template size in the frame of the list
+	itemMinSizes      [2]float32 // theme text size and padding when itemMin was measured, see updateItemMin
+	headerMinSizes    [2]float32 // theme text size and padding when headerMin was measured
+	itemHeights       map[ListItemID]float32
+	measuredHeights   map[ListItemID]float32 // cached by AutoSizeItems
+	widestRow         float32                // widest row content shown, for ScrollWideRows
+	placeholders      int                    // number of skeleton rows shown instead of the data
+	preallocate       int                    // rows to create in the pool when the list is shown, see PreallocateItems
+	context           any                    // passed to UpdateItemWith, see SetContext
+	dataUpdater       *boundListUpdater      // set by NewListWithData
+	selectionBinding  binding.IntList        // see BindSelection
+	selectionListener binding.DataListener
+	selectedBinding   binding.Int // see BindSelected
+	selectedListener  binding.DataListener
+	pager             *pageProvider              // set by SetPageProvider
+	emptyContent      fyne.CanvasObject          // shown when there are no rows
+	header, footer    fyne.CanvasObject          // scroll with the rows
+	collapsedSections map[ListItemID]bool        // keyed by the first item of each collapsed section
+	checks            map[ListItemID]CheckState  // items that are not unchecked, see SetItemCheckState
+	filter            func(id ListItemID) bool   // see SetFilter
+	sortLess          func(a, b ListItemID) bool // see SetSortOrder
+	matches           []ListItemID               // see Search
+	matchIndex        int
+	sectionRows       sectionRows
+	groupBy           func(ListItemID) string // set by SetGroupBy
+	pinned            []ListItemID            // sorted
+	indexLabels       []string                // shown by the index bar, if any
+	indexKey          func(ListItemID) string
+	indexTargets      []ListItemID // first item of each index label, see indexLabelTargets
+	indexTargetsLen   int          // the list length when indexTargets were worked out
+	indexBar          *indexBar
+	scrollBar         *listScrollBar // used instead of the scroller's bar with a custom ScrollBar style
+	reachedEnd        bool           // OnReachedEnd was called and the list has not changed since
+	reachedEndLen     int            // the list length when OnReachedEnd was last called
+	frontierCalled    bool           // OnFrontierReached was called and no more items have arrived since
+	frontierLen       int            // the items that had arrived when OnFrontierReached was last called
+	heightIndex       heightIndex    // row offsets when heights vary
+	itemWidth         float32        // width passed to HeightForWidth
+	offsetY           float32
+	scrollAnim        *fyne.Animation // started by ScrollToAnimated
+	snapTimer         *eventTimer     // started when scrolled with SnapToRows
+	snapping          bool            // animating to the nearest row for SnapToRows
+	refreshPending    bool            // a refresh is scheduled by RefreshSoon, protected by propertyLock
+	typeAhead         string          // characters typed for the type-ahead search
+	typeAheadAt       time.Time       // when the last character was typed
+	offsetUpdated     func(fyne.Position)
+
+	// set by TreeList and GridWrapList
+	keyHandler func(*fyne.KeyEvent) bool // handles keys before the list, returning true if handled
+
+	// set by TreeList
+	canDropInto func(id ListItemID) bool              // whether a dragged row can be dropped into the row
+	dropHandler func(from, insertAt, into ListItemID) // handles drops instead of the list; into is -1 if none
+
+	// set by GridWrapList
+	cellFocus bool // the items within the rows show the keyboard focus, rather than the rows
 }
 
 // NewList creates and returns a list widget for displaying items in
@@ -70,39 +536,284 @@ func NewList(length func() int, createItem func() fyne.CanvasObject, updateItem
 	return list
 }
 
+// ReorderableAdapter is a data source that a reorderable list can
+// rearrange when the user drags and drops rows.
+//
+// Since: Not a core Fyne list API
+type ReorderableAdapter interface {
+	// Len returns the number of items.
+	Len() int
+	// Move moves the item at index from so that it ends up at index to.
+	Move(from, to int)
+}
+
+// listNotifier is implemented by adapters that update lists themselves when changed
+type listNotifier interface {
+	notifiesList(*List) bool
+}
+
+// NewReorderableList creates a list with dragging enabled that displays the items of adapter.
+// When a row is dropped the list calls adapter.Move, keeps the selection and focus
+// on the moved item and refreshes itself, before calling OnDragEnd if set.
+//
+// Since: Not a core Fyne list API
+func NewReorderableList(adapter ReorderableAdapter, createItem func() fyne.CanvasObject, updateItem func(ListItemID, fyne.CanvasObject)) *List {
+	list := NewList(adapter.Len, createItem, updateItem)
+	list.EnableDragging = true
+	list.reorderAdapter = adapter
+	return list
+}
+
 // NewListWithData creates a new list widget that will display the contents of the provided data.
 //
 // Since: 2.0
 func NewListWithData(data binding.DataList, createItem func() fyne.CanvasObject, updateItem func(binding.DataItem, fyne.CanvasObject)) *List {
-	l := NewList(
-		data.Length,
-		createItem,
-		func(i ListItemID, o fyne.CanvasObject) {
-			item, err := data.GetItem(i)
-			if err != nil {
-				fyne.LogError(fmt.Sprintf("Error getting data item %d", i), err)
+	l := NewList(data.Length, createItem, nil)
+	u := &boundListUpdater{list: l, data: data, length: -1, items: make(map[ListItemID]boundListItem),
+		bound: make(map[ListItemID]*boundHeight), heights: make(map[ListItemID]float32)}
+	l.dataUpdater = u
+	l.UpdateItem = func(i ListItemID, o fyne.CanvasObject) {
+		item, err := data.GetItem(i)
+		if err != nil {
+			fyne.LogError(fmt.Sprintf("Error getting data item %d", i), err)
+			return
+		}
+		u.watchItem(i, item)
+		updateItem(item, o)
+	}
+
+	data.AddListener(binding.NewDataListener(u.listChanged))
+	return l
+}
+
+// boundListUpdater refreshes a list created with NewListWithData as little as possible.
+// Data lists only notify their listeners when their length changes, so the list is
+// laid out again without re-binding the existing rows, while changes to individual
+// items refresh only the row bound to that item.
+type boundListUpdater struct {
+	list *List
+	data binding.DataList
+
+	lock    sync.Mutex
+	length  int
+	items   map[ListItemID]boundListItem // items bound to the rows shown
+	bound   map[ListItemID]*boundHeight  // height bindings of the items shown, from List.ItemHeightBinding
+	heights map[ListItemID]float32       // the last heights read from the bindings, kept once rows are recycled
+}
+
+type boundListItem struct {
+	item     binding.DataItem
+	listener binding.DataListener
+}
+
+type boundHeight struct {
+	item     binding.DataItem
+	height   binding.Float // nil if the item has the default height
+	listener binding.DataListener
+}
+
+func (u *boundListUpdater) listChanged() {
+	length := u.data.Length()
+	u.lock.Lock()
+	oldLength := u.length
+	u.length = length
+	for id, watched := range u.items {
+		if id >= length {
+			watched.item.RemoveListener(watched.listener)
+			delete(u.items, id)
+		}
+	}
+	for id, h := range u.bound {
+		if id >= length {
+			h.unwatch()
+			delete(u.bound, id)
+		}
+	}
+	u.lock.Unlock()
+	if oldLength < 0 {
+		// the first call, made when the listener is added; the list reads the length itself
+		return
+	}
+
+	// this is called on the binding goroutine, so the list is updated on its event goroutine
+	u.list.runOnEventQueue(func() {
+		u.list.propertyLock.Lock()
+		if oldLength != length {
+			// items may have moved to other IDs, so only the heights of the rows shown,
+			// whose bindings are watched, are still known. They are dropped together with
+			// the height index, so that it isn't updated from heights it wasn't built with.
+			u.lock.Lock()
+			for id := range u.heights {
+				if _, ok := u.bound[id]; !ok {
+					delete(u.heights, id)
+				}
+			}
+			u.lock.Unlock()
+		}
+		u.list.heightIndex.valid = false
+		u.list.propertyLock.Unlock()
+		if oldLength == length {
+			u.list.Refresh()
+			return
+		}
+		u.list.refreshLayout()
+	})
+}
+
+// watches an item so that only its row is refreshed when it changes, until the row is recycled
+func (u *boundListUpdater) watchItem(id ListItemID, item binding.DataItem) {
+	u.watchHeight(id, item)
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if watched, ok := u.items[id]; ok {
+		if watched.item == item {
+			return
+		}
+		watched.item.RemoveListener(watched.listener)
+	}
+
+	var initial atomic.Bool // listeners are called once when added, and the row is being updated already
+	initial.Store(true)
+	listener := binding.NewDataListener(func() {
+		if initial.CompareAndSwap(true, false) {
+			return
+		}
+		u.list.runOnEventQueue(func() { u.list.RefreshRange(id, id) })
+	})
+	u.items[id] = boundListItem{item: item, listener: listener}
+	item.AddListener(listener)
+}
+
+// watches the binding from ItemHeightBinding for an item whose row is being bound,
+// so that the list is laid out again when it changes. Only the rows shown are watched,
+// see rowRecycled, and the other items keep the last height read for them.
+func (u *boundListUpdater) watchHeight(id ListItemID, item binding.DataItem) {
+	f := u.list.ItemHeightBinding
+	if f == nil {
+		return
+	}
+	u.lock.Lock()
+	h, ok := u.bound[id]
+	u.lock.Unlock()
+	if ok && h.item == item {
+		return
+	}
+
+	h = &boundHeight{item: item, height: f(id, item)} // no lock is held while calling user code
+	u.lock.Lock()
+	if old, ok := u.bound[id]; ok {
+		old.unwatch()
+	}
+	u.bound[id] = h
+	if h.height != nil {
+		var initial atomic.Bool // listeners are called once when added, and the height is read below
+		initial.Store(true)
+		h.listener = binding.NewDataListener(func() {
+			if initial.CompareAndSwap(true, false) {
 				return
 			}
-			updateItem(item, o)
+			u.list.runOnEventQueue(func() { u.readHeight(id, h) })
 		})
+		h.height.AddListener(h.listener)
+	}
+	u.lock.Unlock()
+	u.readHeight(id, h)
+}
 
-	data.AddListener(binding.NewDataListener(l.Refresh))
-	return l
+// stops watching an item, and its height binding, when its row is recycled
+func (u *boundListUpdater) rowRecycled(id ListItemID) {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if watched, ok := u.items[id]; ok {
+		watched.item.RemoveListener(watched.listener)
+		delete(u.items, id)
+	}
+	if h, ok := u.bound[id]; ok {
+		h.unwatch()
+		delete(u.bound, id)
+	}
+}
+
+func (h *boundHeight) unwatch() {
+	if h.height != nil {
+		h.height.RemoveListener(h.listener)
+	}
+}
+
+// reads the height of an item from its binding, laying the list out again if it has changed
+func (u *boundListUpdater) readHeight(id ListItemID, h *boundHeight) {
+	var height float32
+	known := false
+	if h.height != nil {
+		value, err := h.height.Get()
+		if err != nil {
+			fyne.LogError(fmt.Sprintf("Error getting the height of data item %d", id), err)
+		} else {
+			height, known = float32(value), true
+		}
+	}
+
+	l := u.list
+	l.propertyLock.Lock()
+	u.lock.Lock()
+	old, wasKnown := u.heights[id]
+	current := u.bound[id] == h // false if the row has been recycled or bound to another item
+	u.lock.Unlock()
+	if !current || (known == wasKnown && height == old) {
+		l.propertyLock.Unlock()
+		return
+	}
+	oldHeight := l.itemHeight(id)
+	u.lock.Lock()
+	if known {
+		u.heights[id] = height
+	} else {
+		delete(u.heights, id)
+	}
+	u.lock.Unlock()
+	l.itemHeightChanged(id, oldHeight)
+	var lo *listLayout
+	if l.scroller != nil {
+		lo = l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+		lo.measuredChanged = true
+	}
+	l.propertyLock.Unlock()
+	if lo != nil {
+		lo.queueMeasuredHeights()
+	}
+}
+
+// returns the last height read from the binding from ItemHeightBinding for the item.
+// ok is false if the item has no binding, or its row hasn't been shown.
+// The caller must hold the propertyLock.
+func (u *boundListUpdater) height(id ListItemID) (height float32, ok bool) {
+	if u.list.ItemHeightBinding == nil {
+		return 0, false
+	}
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	height, ok = u.heights[id]
+	return height, ok
 }
 
 // CreateRenderer is a private method to Fyne which links this widget to its renderer.
 func (l *List) CreateRenderer() fyne.WidgetRenderer {
 	l.ExtendBaseWidget(l)
 
-	if f := l.CreateItem; f != nil && l.itemMin.IsZero() {
-		l.itemMin = f().MinSize()
-	}
+	l.updateItemMin()
 
 	ll := newListLayout(l)
+	ll.(*listLayout).preallocate(l.preallocate)
 	layout := &fyne.Container{Layout: ll}
-	l.scroller = container.NewVScroll(layout)
+	if l.horizontal {
+		l.scroller = container.NewHScroll(layout)
+	} else {
+		l.scroller = container.NewVScroll(layout)
+	}
 	layout.Resize(layout.MinSize())
-	objects := []fyne.CanvasObject{l.scroller, &ll.(*listLayout).dragSeparator}
+	l.indexBar = newIndexBar(l)
+	l.scrollBar = newListScrollBar(l)
+	objects := []fyne.CanvasObject{l.scroller, ll.(*listLayout).pinnedStrip, ll.(*listLayout).emptyState, l.scrollBar, l.indexBar, ll.(*listLayout).drag.ghost, ll.(*listLayout).drag.indicator, ll.(*listLayout).tooltip}
 	return newListRenderer(objects, l, l.scroller, layout)
 }
 
@@ -119,10 +830,30 @@ func (l *List) FocusGained() {
 //
 // Implements: fyne.Focusable
 func (l *List) FocusLost() {
-	l.focused = false
+	l.exitRow()
+	l.focused, l.shiftDown = false, false
 	l.RefreshItem(l.currentFocus)
 }
 
+// FocusedItem returns the item which has the keyboard focus when the list is focused.
+//
+// Since: Not a core Fyne list API
+func (l *List) FocusedItem() ListItemID {
+	return l.currentFocus
+}
+
+// SetFocusedItem moves the keyboard focus within the list to the item and scrolls it
+// into view, such as to restore the focus after reloading the data. It does not focus
+// the list itself in the canvas.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetFocusedItem(id ListItemID) {
+	if id < 0 || id >= l.length() {
+		return
+	}
+	l.moveFocus(id)
+}
+
 // MinSize returns the size that this widget should not shrink below.
 func (l *List) MinSize() fyne.Size {
 	l.ExtendBaseWidget(l)
@@ -140,572 +871,2735 @@ func (l *List) RefreshItem(id ListItemID) {
 	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
 	lo.renderLock.RLock() // ensures we are not changing visible info in render code during the search
 	item, ok := lo.searchVisible(lo.visible, id)
+	if !ok {
+		item, ok = lo.searchVisible(lo.pinnedRows, id)
+	}
 	lo.renderLock.RUnlock()
 	if ok {
 		lo.setupListItem(item, id, l.focused && l.currentFocus == id)
+		lo.applyMeasuredHeights()
 	}
 }
 
-// Returns the item that is currently bound to the given ID,
-// or none of the ID is currently out of the visible range of the list.
+// SetContext sets the value passed to UpdateItemWith and updates all rows with it.
 //
 // Since: Not a core Fyne list API
-func (l *List) ItemForID(id ListItemID) fyne.CanvasObject {
-	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
-	lo.renderLock.RLock() // ensures we are not changing visible info in render code during the search
-	item, ok := lo.searchVisible(lo.visible, id)
-	lo.renderLock.RUnlock()
-	if ok {
-		return item.child
+func (l *List) SetContext(ctx any) {
+	l.propertyLock.Lock()
+	l.context = ctx
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// binds the row content to the item with UpdateItemWith or UpdateItem
+func (l *List) updateItem(id ListItemID, item fyne.CanvasObject) {
+	if f := l.UpdateItemWith; f != nil {
+		l.propertyLock.RLock()
+		ctx := l.context
+		l.propertyLock.RUnlock()
+		f(id, item, ctx)
+	} else if f := l.UpdateItem; f != nil {
+		f(id, item)
 	}
-	return nil
 }
 
-// SetItemHeight supports changing the height of the specified list item. Items normally take the height of the template
-// returned from the CreateItem callback. The height parameter uses the same units as a fyne.Size type and refers
-// to the internal content height not including the divider size.
+// how long RefreshSoon gathers refresh requests for, about a frame
+const refreshSoonDelay = time.Second / 60
+
+// RefreshSoon refreshes the list within about a frame, so that a burst of calls, such as one for
+// each change notification from a data layer, updates the rows only once. It is safe to call
+// from any goroutine.
 //
-// Since: 2.3
-func (l *List) SetItemHeight(id ListItemID, height float32) {
+// Since: Not a core Fyne list API
+func (l *List) RefreshSoon() {
 	l.propertyLock.Lock()
-
-	if l.itemHeights == nil {
-		l.itemHeights = make(map[ListItemID]float32)
-	}
-
-	refresh := l.itemHeights[id] != height
-	l.itemHeights[id] = height
+	pending := l.refreshPending
+	l.refreshPending = true
 	l.propertyLock.Unlock()
-
-	if refresh {
-		l.RefreshItem(id)
+	if pending {
+		return
 	}
+	time.AfterFunc(refreshSoonDelay, func() {
+		l.propertyLock.Lock()
+		l.refreshPending = false
+		l.propertyLock.Unlock()
+		l.Refresh()
+	})
 }
 
-func (l *List) scrollTo(id ListItemID) {
-	if l.scroller == nil {
-		return
+// Dropped handles URIs, such as files, dragged onto the window from the desktop.
+// Fyne windows deliver drops through a single handler, so apps should call this
+// from the callback passed to fyne.Window.SetOnDropped. If pos, in absolute canvas
+// coordinates, is within the list and OnURIsDropped is set, it is called with the
+// ID of the item the URIs should be inserted before and true is returned.
+//
+// Since: Not a core Fyne list API
+func (l *List) Dropped(pos fyne.Position, uris []fyne.URI) bool {
+	if l.scroller == nil || l.OnURIsDropped == nil || !l.Visible() {
+		return false
 	}
-
-	separatorThickness := theme.Padding()
-	y := float32(0)
-	lastItemHeight := l.itemMin.Height
-	if l.itemHeights == nil || len(l.itemHeights) == 0 {
-		y = (float32(id) * l.itemMin.Height) + (float32(id) * separatorThickness)
-	} else {
-		for i := 0; i < id; i++ {
-			height := l.itemMin.Height
-			if h, ok := l.itemHeights[i]; ok {
-				height = h
-			}
-
-			y += height + separatorThickness
-			lastItemHeight = height
-		}
+	listPos := fyne.CurrentApp().Driver().AbsolutePositionForObject(l)
+	relPos := pos.Subtract(listPos)
+	size := l.Size()
+	if relPos.X < 0 || relPos.Y < 0 || relPos.X > size.Width || relPos.Y > size.Height {
+		return false
 	}
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	insertAt, _ := lo.insertionPoint(l.orientPos(relPos).Y)
+	l.OnURIsDropped(insertAt, uris)
+	return true
+}
 
-	if y < l.scroller.Offset.Y {
-		l.scroller.Offset.Y = y
-	} else if y+l.itemMin.Height > l.scroller.Offset.Y+l.scroller.Size().Height {
-		l.scroller.Offset.Y = y + lastItemHeight - l.scroller.Size().Height
-	}
-	l.offsetUpdated(l.scroller.Offset)
+// IsDragging returns whether a row of the list is currently being dragged to reorder it.
+//
+// Since: Not a core Fyne list API
+func (l *List) IsDragging() bool {
+	_, ok := l.DraggingItem()
+	return ok
 }
 
-// Resize is called when this list should change size. We refresh to ensure invisible items are drawn.
-func (l *List) Resize(s fyne.Size) {
-	l.BaseWidget.Resize(s)
+// DraggingItem returns the ID of the row being dragged, and true
+// if a drag is in progress.
+//
+// Since: Not a core Fyne list API
+func (l *List) DraggingItem() (ListItemID, bool) {
 	if l.scroller == nil {
-		return
+		return 0, false
 	}
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	if id := lo.draggingRow; id >= 0 {
+		return id, true
+	}
+	return 0, false
+}
 
-	l.offsetUpdated(l.scroller.Offset)
-	l.scroller.Content.(*fyne.Container).Layout.(*listLayout).updateList(true)
+// DropTarget is an object outside of a list that rows can be dragged onto.
+//
+// Since: Not a core Fyne list API
+type DropTarget interface {
+	fyne.CanvasObject
+
+	// Drop is called with the result of DragDataForItem
+	// when a row is dropped onto the target.
+	Drop(data any)
 }
 
-// Select add the item identified by the given ID to the selection.
-func (l *List) Select(id ListItemID) {
-	if len(l.selected) > 0 && id == l.selected[0] {
-		return
+// AddDropTarget registers a target that rows from this list can be dragged out to.
+// A row dropped onto a visible target is delivered to it instead of being reordered.
+//
+// Since: Not a core Fyne list API
+func (l *List) AddDropTarget(target DropTarget) {
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	l.dropTargets = append(l.dropTargets, target)
+}
+
+// RemoveDropTarget unregisters a target previously added with AddDropTarget.
+//
+// Since: Not a core Fyne list API
+func (l *List) RemoveDropTarget(target DropTarget) {
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	for i, t := range l.dropTargets {
+		if t == target {
+			l.dropTargets = append(l.dropTargets[:i], l.dropTargets[i+1:]...)
+			return
+		}
 	}
-	length := 0
-	if f := l.Length; f != nil {
-		length = f()
+}
+
+// AddDragRemoveTarget registers an object, such as a trash icon, that rows from this list can
+// be dragged onto to remove them. OnDragRemoved is called with the item of a row dropped onto it.
+//
+// Since: Not a core Fyne list API
+func (l *List) AddDragRemoveTarget(target fyne.CanvasObject) {
+	l.AddDropTarget(&removeTarget{CanvasObject: target})
+}
+
+// RemoveDragRemoveTarget unregisters a target previously added with AddDragRemoveTarget.
+//
+// Since: Not a core Fyne list API
+func (l *List) RemoveDragRemoveTarget(target fyne.CanvasObject) {
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	for i, t := range l.dropTargets {
+		if r, ok := t.(*removeTarget); ok && r.CanvasObject == target {
+			l.dropTargets = append(l.dropTargets[:i], l.dropTargets[i+1:]...)
+			return
+		}
 	}
-	if id < 0 || id >= length {
+}
+
+// removeTarget is a drop target added with AddDragRemoveTarget
+type removeTarget struct {
+	fyne.CanvasObject
+}
+
+// Drop does nothing, as the list calls OnDragRemoved for rows dropped onto remove targets.
+func (r *removeTarget) Drop(any) {}
+
+// returns the registered drop target under the absolute position pos, if any
+func (l *List) dropTargetAt(pos fyne.Position) DropTarget {
+	l.propertyLock.RLock()
+	defer l.propertyLock.RUnlock()
+	d := fyne.CurrentApp().Driver()
+	for _, t := range l.dropTargets {
+		if !t.Visible() {
+			continue
+		}
+		rel := pos.Subtract(d.AbsolutePositionForObject(t))
+		if size := t.Size(); rel.X >= 0 && rel.Y >= 0 && rel.X <= size.Width && rel.Y <= size.Height {
+			return t
+		}
+	}
+	return nil
+}
+
+// refreshLayout lays the list out again after its length changed,
+// updating only the rows that were not visible before.
+func (l *List) refreshLayout() {
+	if l.scroller == nil {
 		return
 	}
-	old := l.selected
-	l.selected = []ListItemID{id}
-	defer func() {
-		if f := l.OnUnselected; f != nil && len(old) > 0 {
-			f(old[0])
-		}
-		if f := l.OnSelected; f != nil {
-			f(id)
+	l.scroller.Refresh() // resize the content to its new min size
+	l.scroller.Content.(*fyne.Container).Layout.(*listLayout).updateList(true)
+	l.refreshScrollBar()
+}
+
+// updates the custom scroll bar, if any, for the current content size and scroll offset
+func (l *List) refreshScrollBar() {
+	if l.scrollBar != nil && l.ScrollBar.isCustom() {
+		l.scrollBar.Refresh()
+	}
+}
+
+// RefreshRange updates the visible items with IDs from start to end, inclusive,
+// without refreshing the rest of the list. Use RefreshItem or Refresh instead
+// if the heights of the items have changed.
+//
+// Since: Not a core Fyne list API
+func (l *List) RefreshRange(start, end ListItemID) {
+	if l.scroller == nil || end < start {
+		return
+	}
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	inRange := lo.takeBuffer()
+	lo.renderLock.RLock()
+	first := 0
+	if l.sortLess == nil {
+		first = sort.Search(len(lo.visible), func(i int) bool { return lo.visible[i].id >= start })
+	}
+	for _, vis := range lo.visible[first:] {
+		if vis.id >= start && vis.id <= end {
+			inRange = append(inRange, vis)
+		} else if vis.id > end && l.sortLess == nil {
+			break
 		}
-	}()
-	l.scrollTo(id)
-	l.Refresh()
+	}
+	lo.renderLock.RUnlock() // user code should not be locked
+
+	for _, vis := range inRange {
+		lo.setupListItem(vis.item, vis.id, l.focused && l.currentFocus == vis.id)
+	}
+	lo.refreshPinned(start, end)
+	lo.applyMeasuredHeights()
+
+	lo.putBuffer(inRange)
 }
 
-// ScrollTo scrolls to the item represented by id
+// Returns the item that is currently bound to the given ID,
+// or none of the ID is currently out of the visible range of the list.
 //
-// Since: 2.1
-func (l *List) ScrollTo(id ListItemID) {
-	length := 0
-	if f := l.Length; f != nil {
-		length = f()
+// Since: Not a core Fyne list API
+func (l *List) ItemForID(id ListItemID) fyne.CanvasObject {
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	lo.renderLock.RLock() // ensures we are not changing visible info in render code during the search
+	item, ok := lo.searchVisible(lo.visible, id)
+	if !ok {
+		item, ok = lo.searchVisible(lo.pinnedRows, id)
 	}
-	if id < 0 || id >= length {
+	lo.renderLock.RUnlock()
+	if ok {
+		return item.child
+	}
+	return nil
+}
+
+// VisibleItemRange returns the IDs of the first and last items with rows on screen,
+// not counting rows created ahead of time for OverscanRows or pinned rows.
+// Both are -1 if no rows are visible.
+//
+// Since: Not a core Fyne list API
+func (l *List) VisibleItemRange() (first, last ListItemID) {
+	first, last = -1, -1
+	if l.scroller == nil {
 		return
 	}
-	l.scrollTo(id)
-	l.Refresh()
+	top, bottom := l.offsetY, l.offsetY+l.orient(l.scroller.Size()).Height
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	lo.renderLock.RLock()
+	defer lo.renderLock.RUnlock()
+	for _, vis := range lo.visible {
+		if vis.item.layoutY+l.orient(vis.item.Size()).Height <= top || vis.item.layoutY >= bottom {
+			continue
+		}
+		if first < 0 {
+			first = vis.id
+		}
+		last = vis.id
+	}
+	return first, last
 }
 
-// ScrollToBottom scrolls to the end of the list
+// ItemPosition returns the Y position of the top of the row showing the item, relative to
+// the list, whether or not the row is scrolled into view. In a horizontal list it returns the
+// X position of the leading edge of the item instead. ok is false if the item does not
+// exist or is hidden in a collapsed section.
 //
-// Since: 2.1
-func (l *List) ScrollToBottom() {
-	length := 0
-	if f := l.Length; f != nil {
-		length = f()
+// Since: Not a core Fyne list API
+func (l *List) ItemPosition(id ListItemID) (y float32, ok bool) {
+	if l.scroller == nil || id < 0 || id >= l.length() {
+		return 0, false
 	}
-	if length > 0 {
-		length--
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	lo.renderLock.RLock()
+	pinned, isPinned := lo.searchVisible(lo.pinnedRows, id)
+	lo.renderLock.RUnlock()
+	if isPinned {
+		return l.orientPos(pinned.Position()).Y, true
 	}
-	l.scrollTo(length)
-	l.Refresh()
+
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	l.shownRows(l.length())
+	if _, shown := l.itemRow(id); !shown {
+		return 0, false
+	}
+	return float32(l.itemOffset(id)-float64(l.offsetY)) + l.orientPos(l.scroller.Position()).Y, true
 }
 
-// ScrollToTop scrolls to the start of the list
+// ItemAt returns the ID of the item whose row is at the position, relative to the list.
+// ok is false if there is no row at that position, such as over the header or between rows.
 //
-// Since: 2.1
-func (l *List) ScrollToTop() {
-	l.scrollTo(0)
+// Since: Not a core Fyne list API
+func (l *List) ItemAt(pos fyne.Position) (id ListItemID, ok bool) {
+	if l.scroller == nil {
+		return 0, false
+	}
+	pos = l.orientPos(pos)
+	if pos.X < 0 || pos.X > l.orient(l.Size()).Width {
+		return 0, false
+	}
+	scrollerY := l.orientPos(l.scroller.Position()).Y
+	if pos.Y < scrollerY {
+		lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+		lo.renderLock.RLock()
+		defer lo.renderLock.RUnlock()
+		for _, row := range lo.pinnedRows {
+			if y := l.orientPos(row.item.Position()).Y; pos.Y >= y && pos.Y < y+l.orient(row.item.Size()).Height {
+				return row.id, true
+			}
+		}
+		return 0, false
+	}
+	if pos.Y > scrollerY+l.orient(l.scroller.Size()).Height {
+		return 0, false
+	}
+
+	y := float64(pos.Y-scrollerY) + float64(l.offsetY)
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	id, ok = l.itemAtOffset(y)
+	if !ok {
+		return 0, false
+	}
+	if top := l.itemOffset(id); y < top || y >= top+float64(l.itemHeight(id)) {
+		return 0, false
+	}
+	return id, true
+}
+
+// SetItemHeight supports changing the height of the specified list item. Items normally take the height of the template
+// returned from the CreateItem callback. The height parameter uses the same units as a fyne.Size type and refers
+// to the internal content height not including the divider size.
+//
+// Since: 2.3
+func (l *List) SetItemHeight(id ListItemID, height float32) {
+	l.propertyLock.Lock()
+
+	if l.itemHeights == nil {
+		l.itemHeights = make(map[ListItemID]float32)
+	}
+
+	refresh := l.itemHeights[id] != height
+	oldHeight := l.itemHeight(id)
+	l.itemHeights[id] = height
+	l.itemHeightChanged(id, oldHeight)
+	l.propertyLock.Unlock()
+
+	if refresh {
+		l.RefreshItem(id)
+	}
+}
+
+// SetItemHeights replaces all heights previously set with SetItemHeight with the given
+// heights, keyed by item ID, and refreshes the list once.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetItemHeights(heights map[ListItemID]float32) {
+	l.propertyLock.Lock()
+	l.itemHeights = make(map[ListItemID]float32, len(heights))
+	for id, h := range heights {
+		l.itemHeights[id] = h
+	}
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+
 	l.Refresh()
 }
 
-// ScrollToOffset scrolls the list to the given offset position.
+// InvalidateItemMinSize makes the list measure the templates returned by CreateItem and
+// CreateSectionHeader again, such as after their content changed. Otherwise the list only creates
+// the templates when it is first shown and when the theme's text size or padding change.
 //
-// Since: 2.5
-func (l *List) ScrollToOffset(offset float32) {
-	if l.scroller == nil {
+// Since: Not a core Fyne list API
+func (l *List) InvalidateItemMinSize() {
+	l.propertyLock.Lock()
+	l.itemMinSizes = [2]float32{}
+	l.headerMinSizes = [2]float32{}
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+
+	l.Refresh()
+}
+
+// measures the template returned by CreateItem, unless it has been measured with the
+// current theme sizes, as creating a template can be costly
+func (l *List) updateItemMin() {
+	f := l.CreateItem
+	if f == nil {
 		return
 	}
-	if offset < 0 {
-		offset = 0
+	sizes := [2]float32{theme.TextSize(), theme.Padding()}
+	if sizes == l.itemMinSizes && !l.itemMin.IsZero() {
+		return
 	}
-	contentHeight := l.contentMinSize().Height
-	if l.Size().Height >= contentHeight {
-		return // content fully visible - no need to scroll
+	min := l.orient(f().MinSize())
+	for _, create := range [...]func() fyne.CanvasObject{l.CreateLeadingAccessory, l.CreateTrailingAccessory} {
+		if create != nil {
+			size := l.orient(create().MinSize())
+			min = fyne.NewSize(min.Width+size.Width, fyne.Max(min.Height, size.Height))
+		}
 	}
-	if offset > contentHeight {
-		offset = contentHeight
+	l.propertyLock.Lock()
+	l.itemMin, l.itemMinSizes = min, sizes
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+}
+
+// ClearItemHeights removes all heights set with SetItemHeight or SetItemHeights,
+// returning those items to their default height.
+//
+// Since: Not a core Fyne list API
+func (l *List) ClearItemHeights() {
+	l.propertyLock.Lock()
+	if len(l.itemHeights) == 0 {
+		l.propertyLock.Unlock()
+		return
 	}
-	l.scroller.Offset.Y = offset
-	l.offsetUpdated(l.scroller.Offset)
+	l.itemHeights = nil
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+
 	l.Refresh()
 }
 
-// GetScrollOffset returns the current scroll offset position
+// SetEmptyContent sets an object, such as a label, that is shown centered
+// in the list whenever it has no rows. Pass nil to show nothing.
 //
-// Since: 2.5
-func (l *List) GetScrollOffset() float32 {
-	return l.offsetY
+// Since: Not a core Fyne list API
+func (l *List) SetEmptyContent(obj fyne.CanvasObject) {
+	l.emptyContent = obj
+	l.Refresh()
 }
 
-// TypedKey is called if a key event happens while this List is focused.
+// SetHeader sets an object, such as a search bar, that is shown above the first row
+// and scrolls with the list. Pass nil to remove it.
 //
-// Implements: fyne.Focusable
-func (l *List) TypedKey(event *fyne.KeyEvent) {
-	switch event.Name {
-	case fyne.KeySpace:
-		l.Select(l.currentFocus)
-	case fyne.KeyDown:
-		if f := l.Length; f != nil && l.currentFocus >= f()-1 {
-			return
+// Since: Not a core Fyne list API
+func (l *List) SetHeader(obj fyne.CanvasObject) {
+	l.header = obj
+	l.Refresh()
+}
+
+// SetFooter sets an object, such as a "load more" button, that is shown below the last row
+// and scrolls with the list. Pass nil to remove it.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetFooter(obj fyne.CanvasObject) {
+	l.footer = obj
+	l.Refresh()
+}
+
+// ShowPlaceholders shows n shimmering skeleton rows, sized from the item template,
+// instead of the list data, such as while the data is loading.
+// Placeholder rows cannot be selected or dragged. Call HidePlaceholders to show the data.
+//
+// Since: Not a core Fyne list API
+func (l *List) ShowPlaceholders(n int) {
+	if n < 0 {
+		n = 0
+	}
+	l.propertyLock.Lock()
+	l.placeholders = n
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// HidePlaceholders removes the rows shown by ShowPlaceholders and shows the list data.
+//
+// Since: Not a core Fyne list API
+func (l *List) HidePlaceholders() {
+	l.ShowPlaceholders(0)
+}
+
+// returns the number of rows shown by the list
+func (l *List) length() int {
+	if l.placeholders > 0 {
+		return l.placeholders
+	}
+	return l.dataLength()
+}
+
+// returns the number of items in the list data, which are not shown while placeholders are
+func (l *List) dataLength() int {
+	if p := l.pager; p != nil {
+		return p.length()
+	}
+	if f := l.Length; f != nil {
+		n := f()
+		if est := l.LengthEstimate; est != nil {
+			if total, exact := est(); total > n {
+				return total
+			} else if !exact {
+				return n + 1 // a row for the items still to arrive
+			}
+		}
+		return n
+	}
+	return 0
+}
+
+// returns whether the row with the given ID shows a placeholder instead of an item
+func (l *List) isPlaceholder(id ListItemID) bool {
+	if l.placeholders > 0 {
+		return true
+	}
+	if p := l.pager; p != nil {
+		return !p.isLoaded(id)
+	}
+	return l.LengthEstimate != nil && l.Length != nil && id >= l.Length()
+}
+
+// requests the page containing the item from the page provider, refreshing the list once loaded
+func (l *List) requestPage(id ListItemID) {
+	p := l.pager
+	if p == nil {
+		return
+	}
+	p.request(id, func(retry bool) {
+		l.propertyLock.Lock()
+		current := l.pager == p
+		if current {
+			l.heightIndex.valid = false
 		}
-		l.RefreshItem(l.currentFocus)
-		l.currentFocus++
-		l.scrollTo(l.currentFocus)
-		l.RefreshItem(l.currentFocus)
-	case fyne.KeyUp:
-		if l.currentFocus <= 0 {
+		l.propertyLock.Unlock()
+		if !current {
 			return
 		}
-		l.RefreshItem(l.currentFocus)
-		l.currentFocus--
-		l.scrollTo(l.currentFocus)
-		l.RefreshItem(l.currentFocus)
+		l.Refresh()
+		if retry {
+			// the placeholder rows still shown then request the page again
+			l.afterDelay(pageRetryDelay, l.Refresh)
+		}
+	})
+}
+
+// returns the height of the row showing the item with the given ID, including any section header.
+// The caller must hold the propertyLock.
+func (l *List) itemHeight(id ListItemID) float32 {
+	header, collapsed := l.sectionHeaderHeight(id)
+	if collapsed {
+		return header
+	}
+	return header + l.contentHeight(id)
+}
+
+// returns the height of the content of the item with the given ID.
+// The caller must hold the propertyLock, so ItemHeight and HeightForWidth mustn't call into the list.
+func (l *List) contentHeight(id ListItemID) float32 {
+	if l.isPlaceholder(id) {
+		return l.itemMin.Height
+	}
+	if h, ok := l.itemHeights[id]; ok {
+		return h
+	}
+	if u := l.dataUpdater; u != nil {
+		if h, ok := u.height(id); ok {
+			return h
+		}
+	}
+	if f := l.HeightForWidth; f != nil {
+		return f(id, l.itemWidth)
+	}
+	if f := l.ItemHeight; f != nil {
+		return f(id)
+	}
+	if h, ok := l.measuredHeights[id]; ok {
+		return h
+	}
+	return l.itemMin.Height
+}
+
+// returns the index of row offsets for length items, rebuilding it if it is out of date.
+// The caller must hold the propertyLock for writing.
+func (l *List) rowOffsets(length int, padding float32) *heightIndex {
+	rows := l.shownRows(length)
+	if rows != nil {
+		length = len(rows)
+	}
+	idx := &l.heightIndex
+	if !idx.valid || idx.n != length || idx.padding != padding || idx.templateHeight != l.itemMin.Height {
+		if rows != nil {
+			idx.build(length, padding, func(row int) float32 { return l.itemHeight(rows[row]) })
+		} else {
+			idx.build(length, padding, l.itemHeight)
+		}
+		idx.templateHeight = l.itemMin.Height
+	}
+	return idx
+}
+
+// updates the row offset index after the height of an item may have changed from oldHeight.
+// The caller must hold the propertyLock for writing.
+func (l *List) itemHeightChanged(id ListItemID, oldHeight float32) {
+	if row, shown := l.itemRow(id); shown {
+		if idx := &l.heightIndex; idx.valid && row < idx.n {
+			idx.add(row, l.itemHeight(id)-oldHeight)
+		}
+	}
+}
+
+// returns the space between rows, see RowPadding
+func (l *List) rowPadding() float32 {
+	if l.RowPadding < 0 {
+		return 0
+	}
+	if l.RowPadding > 0 {
+		return l.RowPadding
+	}
+	return theme.Padding()
+}
+
+// returns the Y position of the top of the item within the list content.
+// It is a float64, as float32 positions are whole rows out in lists with millions of items.
+// The caller must hold the propertyLock for writing.
+func (l *List) itemOffset(id ListItemID) float64 {
+	separatorThickness := l.rowPadding()
+	top := float64(l.contentTop())
+	if !l.hasVariableHeights() {
+		return top + float64(id)*float64(l.itemMin.Height+separatorThickness)
+	}
+	length := l.length()
+	offsets := l.rowOffsets(length, separatorThickness)
+	row, _ := l.itemRow(id)
+	return top + offsets.offset(row)
+}
+
+// returns the Y position of the first row within the list content, which is below the header
+func (l *List) contentTop() float32 {
+	if h := l.header; h != nil && h.Visible() {
+		return l.orient(h.MinSize()).Height + l.rowPadding()
+	}
+	return 0
+}
+
+// returns the height of length rows, without the header and footer, as a float64 like itemOffset.
+// The caller must hold the propertyLock for writing.
+func (l *List) rowsHeight(length int) float64 {
+	if length == 0 {
+		return 0
+	}
+	separatorThickness := l.rowPadding()
+	if !l.hasVariableHeights() {
+		return float64(l.itemMin.Height+separatorThickness)*float64(length) - float64(separatorThickness)
+	}
+	return l.rowOffsets(length, separatorThickness).offset(length) - float64(separatorThickness)
+}
+
+// returns the Y position of the footer within the list content for a list of length rows.
+// The caller must hold the propertyLock for writing.
+func (l *List) footerOffset(length int) float32 {
+	y := float64(l.contentTop()) + l.rowsHeight(length)
+	if length > 0 {
+		y += float64(l.rowPadding())
+	}
+	return float32(y)
+}
+
+// returns whether any item may be a different height than the template.
+// The caller must hold the propertyLock.
+func (l *List) hasVariableHeights() bool {
+	if l.placeholders > 0 {
+		return false
+	}
+	return len(l.itemHeights) > 0 || l.HeightForWidth != nil || l.ItemHeight != nil || len(l.measuredHeights) > 0 ||
+		(l.ItemHeightBinding != nil && l.dataUpdater != nil) ||
+		l.hasSections() || len(l.pinned) > 0 || l.filter != nil || l.sortLess != nil
+}
+
+func (l *List) scrollTo(id ListItemID) {
+	l.scrollToAligned(id, ScrollAlignNearest)
+}
+
+// scrolls so that the item is visible at the given position in the list
+func (l *List) scrollToAligned(id ListItemID, align ScrollAlign) {
+	if l.scroller == nil {
+		return
+	}
+	l.setScrollOffset(l.alignedOffset(id, align))
+	l.offsetUpdated(l.scroller.Offset)
+}
+
+// returns the scroll offset that shows the item at the given position in the list
+func (l *List) alignedOffset(id ListItemID, align ScrollAlign) float32 {
+	offset := l.scrollOffset()
+	l.propertyLock.Lock()
+	if l.isPinned(id) {
+		l.propertyLock.Unlock()
+		return offset // always visible
+	}
+	itemHeight := l.itemHeight(id)
+	y := float32(l.itemOffset(id))
+	l.propertyLock.Unlock()
+
+	viewHeight := l.orient(l.scroller.Size()).Height
+	switch align {
+	case ScrollAlignTop:
+		offset = y
+	case ScrollAlignCenter:
+		offset = y + (itemHeight-viewHeight)/2
+	case ScrollAlignBottom:
+		offset = y + itemHeight - viewHeight
+	default:
+		if y < offset {
+			offset = y
+		} else if y+itemHeight > offset+viewHeight {
+			offset = y + itemHeight - viewHeight
+		}
+	}
+	if align != ScrollAlignNearest {
+		// the content can't scroll past its ends
+		if max := l.contentMinSize().Height - viewHeight; offset > max {
+			offset = max
+		}
+		if offset < 0 {
+			offset = 0
+		}
+	}
+	return offset
+}
+
+// moveItem moves the item at from to be inserted before insertAt in the reorder adapter
+func (l *List) moveItem(from, insertAt ListItemID) {
+	to := movedToIndex(from, insertAt)
+	if to == from {
+		return
+	}
+	l.reorderAdapter.Move(from, to)
+	if n, ok := l.reorderAdapter.(listNotifier); ok && n.notifiesList(l) {
+		return // the adapter has already updated the list
+	}
+	for i, id := range l.selected {
+		l.selected[i] = movedItemID(id, from, to)
+	}
+	l.moveChecks(from, to)
+	l.currentFocus = movedItemID(l.currentFocus, from, to)
+	l.Refresh()
+}
+
+// returns the final index of an item at from that is dropped before insertAt
+func movedToIndex(from, insertAt ListItemID) ListItemID {
+	if insertAt > from {
+		return insertAt - 1
+	}
+	return insertAt
+}
+
+// returns the new ID of the item at id after the item at from is moved to to
+func movedItemID(id, from, to ListItemID) ListItemID {
+	switch {
+	case id == from:
+		return to
+	case from < to && id > from && id <= to:
+		return id - 1
+	case to < from && id >= to && id < from:
+		return id + 1
+	}
+	return id
+}
+
+// Move the list to a new position, relative to its parent.
+//
+// Implements: fyne.Widget
+func (l *List) Move(pos fyne.Position) {
+	l.BaseWidget.Move(pos)
+	if l.scroller != nil {
+		l.scroller.Content.(*fyne.Container).Layout.(*listLayout).dragViewFound = time.Time{}
+	}
+}
+
+// Resize is called when this list should change size. We refresh to ensure invisible items are drawn.
+func (l *List) Resize(s fyne.Size) {
+	l.propertyLock.Lock()
+	if width := l.orient(s).Width; width != l.itemWidth {
+		l.itemWidth = width
+		if l.HeightForWidth != nil {
+			l.heightIndex.valid = false
+		}
+	}
+	l.propertyLock.Unlock()
+	l.BaseWidget.Resize(s)
+	if l.scroller == nil {
+		return
+	}
+
+	l.offsetUpdated(l.scroller.Offset)
+	l.scroller.Content.(*fyne.Container).Layout.(*listLayout).updateList(true)
+}
+
+// Select add the item identified by the given ID to the selection.
+func (l *List) Select(id ListItemID) {
+	l.selectID(id, true)
+}
+
+// selects the item like Select, scrolling to it only if scroll is true
+func (l *List) selectID(id ListItemID, scroll bool) {
+	if l.editMode {
+		l.selectAlso(id)
+		return
+	}
+	if len(l.selected) > 0 && id == l.selected[0] {
+		return
+	}
+	length := l.length()
+	if id < 0 || id >= length || l.isPlaceholder(id) {
+		return
+	}
+	old := l.selected
+	l.selected = []ListItemID{id}
+	defer func() {
+		l.updateSelectionBindings()
+		if f := l.OnUnselected; f != nil && len(old) > 0 {
+			f(old[0])
+		}
+		if f := l.OnSelected; f != nil {
+			f(id)
+		}
+	}()
+	if scroll {
+		l.scrollTo(id)
+		if l.scroller != nil {
+			l.scroller.Refresh() // move the content to the new scroll offset
+		}
+	}
+	l.refreshItems(old)
+	l.RefreshRange(id, id)
+}
+
+// refreshes the given items if they are visible, such as after their selection changed
+func (l *List) refreshItems(ids []ListItemID) {
+	for _, id := range ids {
+		l.RefreshRange(id, id)
+	}
+}
+
+// ScrollTo scrolls to the item represented by id
+//
+// Since: 2.1
+func (l *List) ScrollTo(id ListItemID) {
+	length := l.length()
+	if id < 0 || id >= length {
+		return
+	}
+	l.scrollTo(id)
+	l.Refresh()
+}
+
+// ScrollAlign is where ScrollToWithAlignment places an item within the visible area of a list.
+//
+// Since: Not a core Fyne list API
+type ScrollAlign int
+
+const (
+	// ScrollAlignNearest scrolls as little as possible to make the item visible, like ScrollTo.
+	//
+	// Since: Not a core Fyne list API
+	ScrollAlignNearest ScrollAlign = iota
+	// ScrollAlignTop scrolls the item to the top of the list.
+	//
+	// Since: Not a core Fyne list API
+	ScrollAlignTop
+	// ScrollAlignCenter scrolls the item to the middle of the list.
+	//
+	// Since: Not a core Fyne list API
+	ScrollAlignCenter
+	// ScrollAlignBottom scrolls the item to the bottom of the list.
+	//
+	// Since: Not a core Fyne list API
+	ScrollAlignBottom
+)
+
+// ScrollToWithAlignment scrolls to the item represented by id, placing it at the given
+// position within the list as far as the content allows, such as in the center after a search.
+//
+// Since: Not a core Fyne list API
+func (l *List) ScrollToWithAlignment(id ListItemID, align ScrollAlign) {
+	length := l.length()
+	if id < 0 || id >= length {
+		return
+	}
+	l.scrollToAligned(id, align)
+	l.Refresh()
+}
+
+// ScrollToAnimated scrolls to the item represented by id, like ScrollTo, but moves the
+// scroll offset smoothly over the duration d. If onDone is not nil it is called once the
+// animation ends. Another call to ScrollToAnimated stops an animation in progress.
+//
+// Since: Not a core Fyne list API
+func (l *List) ScrollToAnimated(id ListItemID, d time.Duration, onDone func()) {
+	length := l.length()
+	if id < 0 || id >= length || l.scroller == nil {
+		return
+	}
+	l.animateScroll(l.alignedOffset(id, ScrollAlignNearest), d, onDone)
+}
+
+// moves the scroll offset smoothly to the given offset, stopping any previous scroll animation
+func (l *List) animateScroll(to float32, d time.Duration, onDone func()) {
+	if l.scrollAnim != nil {
+		l.scrollAnim.Stop()
+	}
+	from := l.scrollOffset()
+	l.scrollAnim = fyne.NewAnimation(d, func(f float32) {
+		l.setScrollOffset(from + (to-from)*f)
+		l.offsetUpdated(l.scroller.Offset)
+		l.scroller.Refresh()
+		if f == 1 && onDone != nil {
+			onDone()
+		}
+	})
+	l.scrollAnim.Curve = fyne.AnimationEaseInOut
+	l.scrollAnim.Start()
+}
+
+// how long the list waits after the last scroll before snapping to a row, and how long the snap takes
+const (
+	snapDelay    = 150 * time.Millisecond
+	snapDuration = 150 * time.Millisecond
+)
+
+// schedules snapping to the nearest row boundary once scrolling settles, for SnapToRows
+func (l *List) scheduleSnap() {
+	if !l.SnapToRows || l.snapping {
+		return
+	}
+	if l.snapTimer != nil {
+		l.snapTimer.Stop()
+	}
+	l.snapTimer = l.afterDelay(snapDelay, l.snapToRow) // on the event goroutine, like the scroll events
+}
+
+// animates the scroll offset to the row boundary nearest to it
+func (l *List) snapToRow() {
+	if l.scroller == nil {
+		return
+	}
+	if lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout); lo.draggingRow >= 0 {
+		return
+	}
+	offset := l.offsetY
+	l.propertyLock.Lock()
+	top, next := float32(0), l.contentTop()
+	if offset >= next {
+		id, ok := l.itemAtOffset(float64(offset))
+		if !ok {
+			l.propertyLock.Unlock()
+			return
+		}
+		top = float32(l.itemOffset(id))
+		next = top + l.itemHeight(id) + l.rowPadding()
+	}
+	l.propertyLock.Unlock()
+
+	target := top
+	if offset-top > next-offset {
+		target = next
+	}
+	if max := l.contentMinSize().Height - l.orient(l.scroller.Size()).Height; target > max {
+		target = max
+	}
+	if target < 0 {
+		target = 0
+	}
+	if target == offset {
+		return
+	}
+	l.snapping = true
+	l.animateScroll(target, snapDuration, func() {
+		l.snapping = false
+	})
+}
+
+// ScrollToBottom scrolls to the end of the list
+//
+// Since: 2.1
+func (l *List) ScrollToBottom() {
+	if l.footer != nil && l.footer.Visible() && l.scroller != nil {
+		l.setScrollOffset(fyne.Max(l.contentMinSize().Height-l.orient(l.scroller.Size()).Height, 0))
+		l.offsetUpdated(l.scroller.Offset)
+		l.Refresh()
+		return
+	}
+	length := l.length()
+	if length > 0 {
+		length--
+	}
+	l.scrollTo(length)
+	l.Refresh()
+}
+
+// ScrollToTop scrolls to the start of the list
+//
+// Since: 2.1
+func (l *List) ScrollToTop() {
+	if l.scroller != nil {
+		l.setScrollOffset(0)
+		l.offsetUpdated(l.scroller.Offset)
+	}
+	l.Refresh()
+}
+
+// ScrollToOffset scrolls the list to the given offset position.
+//
+// Since: 2.5
+func (l *List) ScrollToOffset(offset float32) {
+	if l.scroller == nil {
+		return
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	contentHeight := l.contentMinSize().Height
+	if l.orient(l.Size()).Height >= contentHeight {
+		return // content fully visible - no need to scroll
+	}
+	if offset > contentHeight {
+		offset = contentHeight
+	}
+	l.setScrollOffset(offset)
+	l.offsetUpdated(l.scroller.Offset)
+	l.Refresh()
+}
+
+// ScrollAnchor returns the item at the top of the visible area and how far, in pixels,
+// the list is scrolled past the top of its row. Unlike the scroll offset, the anchor
+// can be restored with RestoreScrollAnchor after item heights or the data change.
+// The ID is -1 if the list has no rows.
+//
+// Since: Not a core Fyne list API
+func (l *List) ScrollAnchor() (id ListItemID, offsetInItem float32) {
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	id, ok := l.itemAtOffset(float64(l.offsetY))
+	if !ok {
+		return -1, 0
+	}
+	return id, float32(float64(l.offsetY) - l.itemOffset(id))
+}
+
+// RestoreScrollAnchor scrolls so that the list is offsetInItem pixels past the top of the
+// row showing the item, as returned by ScrollAnchor.
+//
+// Since: Not a core Fyne list API
+func (l *List) RestoreScrollAnchor(id ListItemID, offsetInItem float32) {
+	if l.scroller == nil || id < 0 || id >= l.length() {
+		return
+	}
+	l.propertyLock.Lock()
+	y := float32(l.itemOffset(id) + float64(offsetInItem))
+	l.propertyLock.Unlock()
+	if max := l.contentMinSize().Height - l.orient(l.scroller.Size()).Height; y > max {
+		y = max
+	}
+	if y < 0 {
+		y = 0
+	}
+	l.setScrollOffset(y)
+	l.offsetUpdated(l.scroller.Offset)
+	l.Refresh()
+}
+
+// GetScrollOffset returns the current scroll offset position
+//
+// Since: 2.5
+func (l *List) GetScrollOffset() float32 {
+	return l.offsetY
+}
+
+// TypedKey is called if a key event happens while this List is focused.
+//
+// Implements: fyne.Focusable
+func (l *List) TypedKey(event *fyne.KeyEvent) {
+	if l.typedEditKey(event) || l.typedRowKey(event) {
+		return
+	}
+	if f := l.OnItemKeyTyped; f != nil && l.currentFocus < l.length() && f(l.currentFocus, event) {
+		return
+	}
+	if f := l.keyHandler; f != nil && f(event) {
+		return
+	}
+	switch event.Name {
+	case fyne.KeyTab:
+		l.typedListTab()
+	case fyne.KeySpace:
+		if !l.itemEnabled(l.currentFocus) {
+			return
+		}
+		if l.editMode {
+			l.toggleSelected(l.currentFocus)
+		} else {
+			l.Select(l.currentFocus)
+		}
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if l.FocusRowContent && l.enterRow() {
+			return
+		}
+		if f := l.OnItemActivated; f != nil && l.currentFocus < l.length() && !l.isPlaceholder(l.currentFocus) &&
+			l.itemEnabled(l.currentFocus) {
+			f(l.currentFocus)
+		}
+	case fyne.KeyDown, fyne.KeyUp, fyne.KeyRight, fyne.KeyLeft:
+		next, previous := fyne.KeyDown, fyne.KeyUp
+		if l.horizontal {
+			next, previous = fyne.KeyRight, fyne.KeyLeft
+		}
+		switch event.Name {
+		case next:
+			l.moveFocus(l.enabledItem(l.adjacentItem(l.currentFocus, 1), 1))
+		case previous:
+			l.moveFocus(l.enabledItem(l.adjacentItem(l.currentFocus, -1), -1))
+		}
+	case fyne.KeyPageDown, fyne.KeyPageUp:
+		if l.scroller == nil {
+			return
+		}
+		page, dir := l.orient(l.scroller.Size()).Height, 1
+		if event.Name == fyne.KeyPageUp {
+			page, dir = -page, -1
+		}
+		l.propertyLock.Lock()
+		next, ok := l.itemAtOffset(l.itemOffset(l.currentFocus) + float64(page))
+		l.propertyLock.Unlock()
+		if ok {
+			l.moveFocus(l.enabledItem(next, dir))
+		}
+	case fyne.KeyDelete, fyne.KeyBackspace:
+		if f := l.OnDeleteRequested; f != nil && len(l.selected) > 0 {
+			f(append([]ListItemID(nil), l.selected...))
+		}
+	case fyne.KeyHome:
+		l.moveFocus(l.enabledItem(l.edgeItem(false), 1))
+	case fyne.KeyEnd:
+		l.moveFocus(l.enabledItem(l.edgeItem(true), -1))
+	}
+}
+
+// moves the keyboard focus to the item, scrolling it into view
+func (l *List) moveFocus(id ListItemID) {
+	if id < 0 || id == l.currentFocus {
+		return
+	}
+	l.exitRow()
+	l.RefreshItem(l.currentFocus)
+	l.currentFocus = id
+	l.scrollTo(l.currentFocus)
+	l.RefreshItem(l.currentFocus)
+}
+
+// returns whether the item is enabled, see ItemEnabled
+func (l *List) itemEnabled(id ListItemID) bool {
+	f := l.ItemEnabled
+	return f == nil || l.isPlaceholder(id) || f(id)
+}
+
+// returns the first enabled item from id in the direction of dir rows, or -1 if there is none
+func (l *List) enabledItem(id ListItemID, dir int) ListItemID {
+	for id >= 0 && !l.itemEnabled(id) {
+		id = l.adjacentItem(id, dir)
+	}
+	return id
+}
+
+// returns the item shown in the first or last row, or -1 if there are no rows
+func (l *List) edgeItem(last bool) ListItemID {
+	length := l.length()
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	rows := l.rowCount(length)
+	if rows == 0 {
+		return -1
+	}
+	if last {
+		return l.rowItem(rows - 1)
+	}
+	return l.rowItem(0)
+}
+
+// returns the item shown in the row at the Y position within the list content,
+// or the nearest row if y is above or below the rows. ok is false if there are no rows.
+// The caller must hold the propertyLock for writing.
+func (l *List) itemAtOffset(y float64) (id ListItemID, ok bool) {
+	length := l.length()
+	rows := l.rowCount(length)
+	if rows == 0 {
+		return 0, false
+	}
+	y -= float64(l.contentTop())
+	padding := l.rowPadding()
+	row := 0
+	if !l.hasVariableHeights() {
+		row = int(math.Floor(y / float64(l.itemMin.Height+padding)))
+	} else {
+		row = l.rowOffsets(length, padding).rowAt(y)
+	}
+	if row < 0 {
+		row = 0
+	} else if row >= rows {
+		row = rows - 1
+	}
+	return l.rowItem(row), true
+}
+
+// TypedShortcut is called if a shortcut is typed while this List is focused.
+//
+// Implements: fyne.Shortcutable
+func (l *List) TypedShortcut(shortcut fyne.Shortcut) {
+	if f, ok := l.validRowFocus().(fyne.Shortcutable); ok {
+		f.TypedShortcut(shortcut)
+		return
+	}
+	if l.typedClipboardShortcut(shortcut) || l.typedMoveShortcut(shortcut) {
+		return
+	}
+	// the canvas handles the shortcuts of widgets that are not Shortcutable
+	if c, ok := fyne.CurrentApp().Driver().CanvasForObject(l).(fyne.Shortcutable); ok {
+		c.TypedShortcut(shortcut)
+	}
+}
+
+// moves the focused item for Ctrl or Alt with the Up or Down key, or Left or Right if horizontal,
+// if dragging is enabled, returning false if the shortcut is not handled
+func (l *List) typedMoveShortcut(shortcut fyne.Shortcut) bool {
+	s, ok := shortcut.(*desktop.CustomShortcut)
+	if !ok || !l.EnableDragging || l.scroller == nil ||
+		s.Modifier != fyne.KeyModifierControl && s.Modifier != fyne.KeyModifierAlt {
+		return false
+	}
+	next, previous := fyne.KeyDown, fyne.KeyUp
+	if l.horizontal {
+		next, previous = fyne.KeyRight, fyne.KeyLeft
+	}
+	dir := 1
+	switch s.KeyName {
+	case next:
+	case previous:
+		dir = -1
+	default:
+		return false
+	}
+	l.scroller.Content.(*fyne.Container).Layout.(*listLayout).moveFocusedItem(dir)
+	return true
+}
+
+// calls OnCopy, OnCut or OnPaste for a clipboard shortcut, returning false if it is not handled
+func (l *List) typedClipboardShortcut(shortcut fyne.Shortcut) bool {
+	var f func([]ListItemID, fyne.Clipboard)
+	var clipboard fyne.Clipboard
+	switch s := shortcut.(type) {
+	case *fyne.ShortcutCopy:
+		f, clipboard = l.OnCopy, s.Clipboard
+	case *fyne.ShortcutCut:
+		f, clipboard = l.OnCut, s.Clipboard
+	case *fyne.ShortcutPaste:
+		f, clipboard = l.OnPaste, s.Clipboard
+	}
+	if f == nil {
+		return false
+	}
+	ids := append([]ListItemID(nil), l.selected...)
+	if len(ids) == 0 && l.currentFocus < l.length() && !l.isPlaceholder(l.currentFocus) {
+		ids = []ListItemID{l.currentFocus}
+	}
+	f(ids, clipboard)
+	return true
+}
+
+// TypedRune is called if a text event happens while this List is focused.
+//
+// Implements: fyne.Focusable
+func (l *List) TypedRune(r rune) {
+	if f := l.validRowFocus(); f != nil {
+		f.TypedRune(r)
+		return
+	}
+	f := l.TypeAheadText
+	if f == nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(l.typeAheadAt) > typeAheadTimeout {
+		l.typeAhead = ""
+	}
+	l.typeAheadAt = now
+	l.typeAhead += strings.ToLower(string(r))
+
+	length := l.length()
+	if length == 0 {
+		return
+	}
+	start := l.currentFocus
+	if len([]rune(l.typeAhead)) == 1 {
+		start++ // typing the same letter again moves to the next match
+	}
+	for i := 0; i < length; i++ {
+		id := (start + i) % length
+		if l.itemEnabled(id) && strings.HasPrefix(strings.ToLower(f(id)), l.typeAhead) {
+			l.moveFocus(id)
+			return
+		}
+	}
+}
+
+// how long after the last typed character the type-ahead search starts again
+const typeAheadTimeout = time.Second
+
+// Unselect removes the item identified by the given ID from the selection.
+func (l *List) Unselect(id ListItemID) {
+	i := l.selectedIndex(id)
+	if i < 0 {
+		return
+	}
+
+	if len(l.selected) == 1 {
+		l.selected = nil
+	} else {
+		l.selected = append(append(make([]ListItemID, 0, len(l.selected)-1), l.selected[:i]...), l.selected[i+1:]...)
+	}
+	l.RefreshRange(id, id)
+	l.updateSelectionBindings()
+	if f := l.OnUnselected; f != nil {
+		f(id)
+	}
+}
+
+// UnselectAll removes all items from the selection.
+//
+// Since: 2.1
+func (l *List) UnselectAll() {
+	if len(l.selected) == 0 {
+		return
+	}
+
+	selected := l.selected
+	l.selected = nil
+	l.refreshItems(selected)
+	l.updateSelectionBindings()
+	if f := l.OnUnselected; f != nil {
+		for _, id := range selected {
+			f(id)
+		}
+	}
+}
+
+// returns the min size of the list content in the frame of the list
+func (l *List) contentMinSize() fyne.Size {
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	items := l.length()
+	header, footer := l.header != nil && l.header.Visible(), l.footer != nil && l.footer.Visible()
+	if l.Length == nil && items == 0 && !header && !footer {
+		return fyne.NewSize(0, 0)
+	}
+
+	size := fyne.NewSize(l.itemMin.Width, float32(float64(l.contentTop())+l.rowsHeight(items)))
+	if l.ScrollWideRows {
+		size.Width = fyne.Max(size.Width, l.widestRow)
+	}
+	if header {
+		size.Width = fyne.Max(size.Width, l.orient(l.header.MinSize()).Width)
+		if items == 0 {
+			size.Height -= l.rowPadding()
+		}
+	}
+	if footer {
+		min := l.orient(l.footer.MinSize())
+		size = fyne.NewSize(fyne.Max(size.Width, min.Width), l.footerOffset(items)+min.Height)
+	}
+	return size
+}
+
+func (l *listLayout) calculateDragSeparatorY(thickness float32) float32 {
+	if l.list.orient(l.list.scroller.Size()).Height <= 0 {
+		return 0
+	}
+
+	insertAt, y := l.insertionPoint(l.dragRelativeY)
+	l.dragOutsideSection = insertAt < l.dragSectionMin || insertAt > l.dragSectionMax
+	if insertAt < l.dragSectionMin {
+		insertAt, y = l.dragSectionMin, l.insertionY(l.dragSectionMin)
+	} else if insertAt > l.dragSectionMax {
+		insertAt, y = l.dragSectionMax, l.insertionY(l.dragSectionMax)
+	}
+	if insertAt < l.dragInsertMin {
+		insertAt, y = l.dragInsertMin, l.insertionY(l.dragInsertMin)
+	} else if insertAt > l.dragInsertMax {
+		insertAt, y = l.dragInsertMax, l.insertionY(l.dragInsertMax)
+	}
+	l.dragInsertAt = insertAt
+	if insertAt != l.dragFeedbackAt {
+		if l.dragFeedbackAt >= 0 {
+			l.dragFeedback(DragFeedbackMove)
+		}
+		l.dragFeedbackAt = insertAt
+	}
+	return y - thickness
+}
+
+// returns the first and last positions the dragged item can be inserted before,
+// so that moving it doesn't displace a locked item, see List.ItemLocked
+func (l *listLayout) insertionRange(from ListItemID) (min, max ListItemID) {
+	length := l.list.length()
+	min, max = 0, length
+	if l.list.ItemLocked == nil {
+		return min, max
+	}
+	for id := from - 1; id >= 0; id-- {
+		if l.list.itemLocked(id) {
+			min = id + 1
+			break
+		}
+	}
+	for id := from + 1; id < length; id++ {
+		if l.list.itemLocked(id) {
+			max = id
+			break
+		}
+	}
+	return min, max
+}
+
+// returns the first and last positions the dragged item can be inserted before
+// to stay in its section, see List.ReorderWithinSections
+func (l *listLayout) sectionRange(from ListItemID) (min, max ListItemID) {
+	length := l.list.length()
+	min, max = 0, length
+	if !l.list.ReorderWithinSections || !l.list.hasSections() {
+		return min, max
+	}
+	for min = from; min > 0 && !l.list.isSectionStart(min); min-- {
+	}
+	for max = from + 1; max < length && !l.list.isSectionStart(max); max++ {
+	}
+	return min, max
+}
+
+// returns the Y position in the list content of the middle of the gap above the item,
+// or below the last row if insertAt is the list length, like insertionPoint
+func (l *listLayout) insertionY(insertAt ListItemID) float32 {
+	list := l.list
+	padding := list.rowPadding()
+	top := list.contentTop()
+	list.propertyLock.Lock()
+	defer list.propertyLock.Unlock()
+	if !list.hasVariableHeights() {
+		return top + float32(float64(insertAt)*float64(list.itemMin.Height+padding)) - padding/2
+	}
+	length := list.length()
+	row := list.rowCount(length)
+	if insertAt < length {
+		row, _ = list.itemRow(insertAt)
+	}
+	return float32(float64(top) + list.rowOffsets(length, padding).offset(row) - float64(padding/2))
+}
+
+// returns whether the item is locked in place, see ItemLocked
+func (l *List) itemLocked(id ListItemID) bool {
+	f := l.ItemLocked
+	return f != nil && f(id)
+}
+
+// returns the ID of the item that something dropped at relY (0 == top of list widget)
+// should be inserted before, and the Y position in the list content of the middle
+// of the gap above that item
+func (l *listLayout) insertionPoint(relY float32) (ListItemID, float32) {
+	if relY < 0 {
+		relY = 0
+	} else if h := l.list.orient(l.list.scroller.Size()).Height; relY > h {
+		relY = h
+	}
+
+	numItems := float64(l.list.length())
+	padding := l.list.rowPadding()
+	top := l.list.contentTop()
+	pos := float64(relY) + float64(l.list.offsetY) - float64(top)
+	l.list.propertyLock.Lock()
+	defer l.list.propertyLock.Unlock()
+	if !l.list.hasVariableHeights() {
+		paddedItemHeight := l.list.itemMin.Height + padding
+		beforeItem := math.Round(pos / float64(paddedItemHeight))
+		if beforeItem > numItems {
+			beforeItem = numItems
+		} else if beforeItem < 0 {
+			beforeItem = 0
+		}
+		return ListItemID(beforeItem), top + float32(beforeItem*float64(paddedItemHeight)) - padding/2
+	}
+
+	// insert before the first row whose (padded) midpoint is below the pointer
+	if numItems == 0 {
+		return 0, top - padding/2
+	}
+	offsets := l.list.rowOffsets(int(numItems), padding)
+	beforeRow := offsets.rowAt(pos)
+	if rowOffset := offsets.offset(beforeRow); pos >= rowOffset+float64(l.list.itemHeight(l.list.rowItem(beforeRow))+padding)/2 {
+		beforeRow++
+	}
+	beforeItem := int(numItems)
+	if beforeRow < offsets.n {
+		beforeItem = l.list.rowItem(beforeRow)
+	}
+	return beforeItem, float32(float64(top) + offsets.offset(beforeRow) - float64(padding/2))
+}
+
+// fills l.visibleRowHeights and also returns offY and minRow.
+// The offset of the first row is returned as a float64, as the rows are positioned by adding
+// their heights to it, which in float32 would misplace them in lists with millions of items.
+func (l *listLayout) calculateVisibleRowHeights(itemHeight float32, length int) (offY float64, minRow int) {
+	rowOffset := float64(0)
+	l.visibleRowHeights = l.visibleRowHeights[:0]
+	l.visibleRowIDs = l.visibleRowIDs[:0]
+
+	viewHeight := l.list.orient(l.list.scroller.Size()).Height
+	if viewHeight <= 0 {
+		return
+	}
+
+	// rowPadding may call theme.Padding, which is slow, so we cache it
+	padding := l.list.rowPadding()
+	offsetY := float64(l.list.offsetY) - float64(l.list.contentTop()) // relative to the first row
+
+	if !l.list.hasVariableHeights() {
+		paddedItemHeight := float64(itemHeight + padding)
+
+		minRow = int(math.Floor(offsetY / paddedItemHeight))
+		offY = float64(minRow) * paddedItemHeight
+		maxRow := int(math.Ceil((offY + float64(viewHeight)) / paddedItemHeight))
+		if overscan := l.list.OverscanRows; overscan > 0 {
+			minRow -= overscan
+			offY = float64(minRow) * paddedItemHeight
+			maxRow += overscan
+		}
+
+		if minRow > length-1 {
+			minRow = length - 1
+		}
+		if minRow < 0 {
+			minRow = 0
+			offY = 0
+		}
+
+		if maxRow > length-1 {
+			maxRow = length - 1
+		}
+
+		for i := 0; i <= maxRow-minRow; i++ {
+			l.visibleRowHeights = append(l.visibleRowHeights, itemHeight)
+			l.visibleRowIDs = append(l.visibleRowIDs, minRow+i)
+		}
+		return
+	}
+
+	if length == 0 {
+		return
+	}
+	overscan := 0
+	if l.list.OverscanRows > 0 {
+		overscan = l.list.OverscanRows
+	}
+	offsets := l.list.rowOffsets(length, padding)
+	minRow = offsets.rowAt(offsetY) - overscan
+	if minRow < 0 {
+		minRow = 0
+	}
+	offY = offsets.offset(minRow)
+	rowOffset = offY
+	for i := minRow; i < offsets.n; i++ {
+		if rowOffset >= offsetY+float64(viewHeight) {
+			if overscan == 0 {
+				break
+			}
+			overscan--
+		}
+		id := l.list.rowItem(i)
+		height := l.list.itemHeight(id)
+		rowOffset += float64(height + padding)
+		l.visibleRowHeights = append(l.visibleRowHeights, height)
+		l.visibleRowIDs = append(l.visibleRowIDs, id)
+	}
+	return
+}
+
+const (
+	// max speed (in units per frame) that the list will scroll when dragging above or below
+	defaultMaxScrollSpeed = 500
+	defaultMinScrollSpeed = 3
+	// how far to drag above or below the top/bottom of the list to reach the max scroll speed
+	defaultScrollAccelerateRange = 250
+)
+
+// TouchDragConfig tunes how a drag on a row of a touch device is told to scroll the list
+// or reorder the row. Zero-valued fields use the default behavior.
+//
+// Since: Not a core Fyne list API
+type TouchDragConfig struct {
+	// Slop is how far a row that can be reordered may move while it is held before
+	// the drag scrolls the list instead of waiting for a long press. Defaults to 8.
+	Slop float32
+	// AxisLock makes drags that start along the list scroll it unless the row has been
+	// long pressed first, while drags that start across the list reorder the row.
+	// Without it, any drag on a row reorders it, unless LongPressToDrag is set.
+	AxisLock bool
+}
+
+func (c TouchDragConfig) withDefaults() TouchDragConfig {
+	if c.Slop <= 0 {
+		c.Slop = defaultTouchSlop
+	}
+	return c
+}
+
+// how far a held row may move before the drag scrolls instead, see TouchDragConfig.Slop
+const defaultTouchSlop = 8
+
+// DragScrollConfig tunes how the list auto-scrolls while a row is dragged
+// near its top or bottom edge. Zero-valued fields use the default behavior.
+//
+// Since: Not a core Fyne list API
+type DragScrollConfig struct {
+	// MaxSpeed is the max speed (in units per frame) that the list will scroll
+	MaxSpeed float32
+	// MinSpeed is the speed the list scrolls at as soon as the edge threshold is crossed
+	MinSpeed float32
+	// AccelerateRange is how far past the edge threshold the pointer must be
+	// dragged to reach MaxSpeed
+	AccelerateRange float32
+	// EdgeThreshold is the distance from the top or bottom of the list
+	// that starts to trigger scrolling. Defaults to half the template item height.
+	EdgeThreshold float32
+}
+
+func (c DragScrollConfig) withDefaults(itemHeight float32) DragScrollConfig {
+	if c.MaxSpeed <= 0 {
+		c.MaxSpeed = defaultMaxScrollSpeed
+	}
+	if c.MinSpeed <= 0 {
+		c.MinSpeed = defaultMinScrollSpeed
+	}
+	if c.AccelerateRange <= 0 {
+		c.AccelerateRange = defaultScrollAccelerateRange
+	}
+	if c.EdgeThreshold <= 0 {
+		c.EdgeThreshold = itemHeight / 2
+	}
+	return c
+}
+
+// returns the speed to scroll at while dragging at y within a view of the given height,
+// negative to scroll up, or 0 if y is not near either edge
+func (c DragScrollConfig) speed(y, viewHeight float32) float32 {
+	animationSpeedCurve := func(x float32) float32 {
+		// scale to domain: x_: [0, 1]
+		accelRange := float64(c.AccelerateRange)
+		x_ := math.Min(math.Abs(float64(x)), accelRange) / accelRange
+		// quadratic, modified by MinSpeed
+		return float32(math.Max(x_*x_*float64(c.MaxSpeed), float64(c.MinSpeed)))
+	}
+
+	// distance from top or bottom of list that starts to trigger scrolling animation
+	scrollStartThreshold := c.EdgeThreshold
+
+	if topThresh := y - scrollStartThreshold; topThresh < 0 {
+		return -animationSpeedCurve(topThresh)
+	} else if bottmThresh := viewHeight - scrollStartThreshold; y > bottmThresh {
+		return animationSpeedCurve(y - bottmThresh)
+	}
+	return 0
+}
+
+func (l *listLayout) onRowDragged(item *listItem, e *fyne.DragEvent) {
+	if !l.list.EnableDragging {
+		return
+	}
+	startedDrag := false
+	if l.draggingRow < 0 /*no drag in progress*/ {
+		if !l.canDragRow(item.id) {
+			return
+		}
+		if !l.drag.passedThreshold(e, l.list.DragStartThreshold) {
+			return
+		}
+		l.stopReorderAnim()
+		l.hideTooltip()
+		l.draggingRow = item.id
+		l.draggedHeight = l.list.orient(item.Size()).Height
+		l.dragInsertMin, l.dragInsertMax = l.insertionRange(item.id)
+		l.dragSectionMin, l.dragSectionMax = l.sectionRange(item.id)
+		l.dragFeedbackAt = -1
+		l.dragViewFound = time.Time{}
+		startedDrag = true
+		l.startDragGhost(item)
+		if l.list.DragIndicator.Mode == DragIndicatorGap {
+			l.ensureStartGapAnim()
+		}
+	}
+
+	l.dragPointer = e.AbsolutePosition
+	listPos, viewTop, viewHeight := l.dragViewport()
+	l.dragRelativeY = l.list.orientPos(e.AbsolutePosition).Y - l.list.orientPos(listPos).Y
+
+	l.dropTarget = l.list.dropTargetAt(e.AbsolutePosition)
+	if l.dropTarget != nil {
+		l.drag.stopScroll()
+		l.drag.indicator.Hide()
+		l.updateDragGhost()
+		if startedDrag {
+			l.dragBegan()
+		}
+		return
+	}
+
+	cfg := l.list.DragScroll.withDefaults(l.list.itemMin.Height)
+	// scroll when near the edges of the part of the list inside the window, which the pointer can reach
+	l.drag.scrollAt(cfg.speed(l.dragRelativeY-viewTop, viewHeight))
+
+	l.updateDragSeparator()
+	l.updateDragGhost()
+	if startedDrag {
+		l.dragBegan()
+	}
+}
+
+// calls OnDragBegin and OnDragFeedback for the drag that has just started
+func (l *listLayout) dragBegan() {
+	if f := l.list.OnDragBegin; f != nil {
+		f(l.draggingRow)
+	}
+	l.dragFeedback(DragFeedbackStart)
+}
+
+func (l *listLayout) dragFeedback(kind DragFeedbackKind) {
+	if f := l.list.OnDragFeedback; f != nil {
+		f(kind)
+	}
+}
+
+// how long the position found by dragViewport is used for
+const dragViewMaxAge = 100 * time.Millisecond
+
+// returns the absolute position of the scroller and the part of it inside the window, relative to it.
+// As finding the position walks the whole canvas, it is found when a drag starts or the list is
+// moved or resized, and then again every dragViewMaxAge, which notices the list being moved
+// without a call to Move, as when a scroller containing the list scrolls during the drag.
+func (l *listLayout) dragViewport() (pos fyne.Position, top, height float32) {
+	if time.Since(l.dragViewFound) > dragViewMaxAge {
+		list := l.list
+		l.dragView = fyne.CurrentApp().Driver().AbsolutePositionForObject(list.scroller)
+		top, bottom := float32(0), list.orient(list.scroller.Size()).Height
+		if c := fyne.CurrentApp().Driver().CanvasForObject(list); c != nil {
+			y := list.orientPos(l.dragView).Y
+			top = fyne.Max(top, -y)
+			bottom = fyne.Min(bottom, list.orient(c.Size()).Height-y)
+		}
+		l.dragViewTop, l.dragViewHeight = top, fyne.Max(bottom-top, 0)
+		l.dragViewFound = time.Now()
+	}
+	return l.dragView, l.dragViewTop, l.dragViewHeight
+}
+
+func (l *listLayout) onDragEnd() {
+	l.drag.pending = false
+	if l.draggingRow < 0 {
+		return
+	}
+	startRow := l.draggingRow
+	into := l.dropInto
+	l.setDropInto(-1)
+	l.dragFeedback(DragFeedbackDrop)
+	var oldY map[ListItemID]float32
+	if l.list.AnimateReorder {
+		oldY = l.visibleItemYs()
+		if !l.drag.ghost.Hidden {
+			oldY[startRow] = l.list.orientPos(l.drag.ghost.Position()).Y - l.list.orientPos(l.list.scroller.Position()).Y + l.list.offsetY
+		}
+	}
+	l.drag.end()
+	l.draggingRow = -1
+	l.closeDragGap()
+	if target := l.dropTarget; target != nil {
+		l.dropTarget = nil
+		if _, remove := target.(*removeTarget); remove {
+			if f := l.list.OnDragRemoved; f != nil {
+				f(startRow)
+			}
+			return
+		}
+		if f := l.list.DragDataForItem; f != nil {
+			target.Drop(f(startRow))
+		}
+		return
+	}
+	if f := l.list.dropHandler; f != nil {
+		f(startRow, l.dragInsertAt, into)
+		return
+	}
+	l.reorder(startRow, l.dragInsertAt, oldY)
+}
+
+// moves the item at from to before insertAt through the adapter and OnDragEnd, and records the move.
+// If oldY is not nil, the rows slide from the positions it holds.
+func (l *listLayout) reorder(from, insertAt ListItemID, oldY map[ListItemID]float32) {
+	if l.list.reorderAdapter != nil {
+		l.list.moveItem(from, insertAt)
+	}
+	if l.list.OnDragEnd != nil {
+		l.list.OnDragEnd(from, insertAt)
+	}
+	if h := l.list.reorderHistory; h != nil && (l.list.reorderAdapter != nil || l.list.OnDragEnd != nil) {
+		h.record(from, movedToIndex(from, insertAt))
+	}
+	if oldY != nil {
+		l.animateReorder(oldY, from, movedToIndex(from, insertAt))
+	}
+}
+
+// moves the focused item one row down, or up if dir is negative, as if it had been dragged there.
+// Items that can't be dragged, or would leave the positions they can be dropped at, aren't moved.
+func (l *listLayout) moveFocusedItem(dir int) {
+	from := l.list.currentFocus
+	if l.draggingRow >= 0 || from < 0 || from >= l.list.length() || !l.canDragRow(from) {
+		return
+	}
+	insertAt := from - 1
+	if dir > 0 {
+		insertAt = from + 2
+	}
+	min, max := l.insertionRange(from)
+	sectionMin, sectionMax := l.sectionRange(from)
+	if insertAt < min || insertAt > max || insertAt < sectionMin || insertAt > sectionMax {
+		return
+	}
+	if f := l.list.dropHandler; f != nil {
+		f(from, insertAt, -1)
+		return
+	}
+	var oldY map[ListItemID]float32
+	if l.list.AnimateReorder {
+		oldY = l.visibleItemYs()
+	}
+	l.reorder(from, insertAt, oldY)
+	if to := movedToIndex(from, insertAt); l.list.currentFocus == from {
+		// the list doesn't own the data, so the focus, selection and check states follow the item here
+		for i, id := range l.list.selected {
+			l.list.selected[i] = movedItemID(id, from, to)
+		}
+		l.list.moveChecks(from, to)
+		l.list.moveFocus(to)
+	} else {
+		l.list.scrollTo(l.list.currentFocus)
+	}
+}
+
+// returns the current Y position of each visible row, keyed by ID
+func (l *listLayout) visibleItemYs() map[ListItemID]float32 {
+	l.renderLock.RLock()
+	defer l.renderLock.RUnlock()
+	ys := make(map[ListItemID]float32, len(l.visible))
+	for _, vis := range l.visible {
+		ys[vis.id] = l.list.orientPos(vis.item.Position()).Y
+	}
+	return ys
+}
+
+// slides visible rows from their positions before the item at from was moved to to
+func (l *listLayout) animateReorder(oldY map[ListItemID]float32, from, to ListItemID) {
+	if from == to {
+		l.stopReorderAnim()
+		return
+	}
+	l.animateRows(oldY, func(id ListItemID) (ListItemID, bool) {
+		return movedItemID(id, to, from), true
+	})
+}
+
+// slides visible rows from the positions in oldY, keyed by the IDs they had before a change.
+// oldID returns the ID each row had before the change, or false if the row was inserted.
+// Inserted rows expand from no height, and the rows held by holdRemovedRows collapse.
+func (l *listLayout) animateRows(oldY map[ListItemID]float32, oldID func(ListItemID) (ListItemID, bool)) {
+	l.stopReorderAnim()
+	l.renderLock.Lock()
+	var removed []*listItem // the rows to release when done, as releaseRemovedRows reuses removedRows
+	if len(l.removedRows) > 0 {
+		removed = append(removed, l.removedRows...)
+	}
+	for _, vis := range l.visible {
+		id, ok := oldID(vis.id)
+		if !ok {
+			vis.item.animHeight = l.list.orient(vis.item.Size()).Height
+		} else if y, ok := oldY[id]; ok {
+			vis.item.animFrom = y - vis.item.layoutY
+		}
+		vis.item.gapShift = vis.item.animFrom
+		vis.item.moveToLayout()
+	}
+	l.resizeAnimatedRows(0)
+	l.updateSeparators()
+	l.renderLock.Unlock()
+
+	l.reorderAnim = fyne.NewAnimation(canvas.DurationShort, func(p float32) {
+		l.renderLock.Lock()
+		for _, vis := range l.visible {
+			vis.item.gapShift = vis.item.animFrom * (1 - p)
+			vis.item.moveToLayout()
+		}
+		l.resizeAnimatedRows(p)
+		if p == 1 {
+			for _, vis := range l.visible {
+				vis.item.animHeight = 0
+			}
+		}
+		l.updateSeparators()
+		l.renderLock.Unlock()
+		if p == 1 {
+			l.releaseRemovedRows(removed)
+		}
+	})
+	l.reorderAnim.Curve = fyne.AnimationEaseOut
+	l.reorderAnim.Start()
+}
+
+// resizes the rows expanding and collapsing at point p of the animation of animateRows.
+// The caller must hold the renderLock.
+func (l *listLayout) resizeAnimatedRows(p float32) {
+	for _, vis := range l.visible {
+		if h := vis.item.animHeight; h > 0 {
+			width := l.list.orient(vis.item.Size()).Width
+			vis.item.Resize(l.list.orient(fyne.NewSize(width, h*p)))
+		}
+	}
+	for _, li := range l.removedRows {
+		width := l.list.orient(li.Size()).Width
+		li.Resize(l.list.orient(fyne.NewSize(width, li.animHeight*(1-p))))
+	}
+}
+
+// takes the visible rows of the items that removed reports as removed out of the layout,
+// so that animateRows can collapse them where they were rather than recycle them
+func (l *listLayout) holdRemovedRows(removed func(ListItemID) bool) {
+	l.stopReorderAnim() // so that animateRows doesn't release the rows held
+	l.renderLock.Lock()
+	visible := l.visible[:0]
+	for _, vis := range l.visible {
+		if !removed(vis.id) {
+			visible = append(visible, vis)
+			continue
+		}
+		vis.item.animHeight = l.list.orient(vis.item.Size()).Height
+		vis.item.layoutY += vis.item.gapShift
+		vis.item.gapShift, vis.item.animFrom = 0, 0
+		vis.item.moveToLayout()
+		l.removedRows = append(l.removedRows, vis.item)
+		if u := l.list.dataUpdater; u != nil {
+			u.rowRecycled(vis.id)
+		}
+	}
+	l.nilOldVisibleSliceData(visible, len(visible), len(l.visible))
+	l.visible = visible
+	l.renderLock.Unlock()
+}
+
+// returns the rows held by holdRemovedRows to the pool, once they have collapsed
+func (l *listLayout) releaseRemovedRows(rows []*listItem) {
+	if len(rows) == 0 {
+		return
+	}
+	released := func(o fyne.CanvasObject) bool {
+		for _, li := range rows {
+			if o == li {
+				return true
+			}
+		}
+		return false
+	}
+	l.renderLock.Lock()
+	kept := l.removedRows[:0]
+	for _, li := range l.removedRows {
+		if !released(li) {
+			kept = append(kept, li)
+		}
+	}
+	for i := len(kept); i < len(l.removedRows); i++ {
+		l.removedRows[i] = nil
+	}
+	l.removedRows = kept
+	c := l.list.scroller.Content.(*fyne.Container)
+	objects := c.Objects[:0]
+	for _, o := range c.Objects {
+		if !released(o) {
+			objects = append(objects, o)
+		}
+	}
+	l.nilOldSliceData(objects, len(objects), len(c.Objects))
+	c.Objects = objects
+	for _, li := range rows {
+		li.animHeight = 0
+		l.itemPool.Put(li, l.list.MaxPooledItems)
 	}
+	l.renderLock.Unlock()
+	c.Refresh()
 }
 
-// TypedRune is called if a text event happens while this List is focused.
-//
-// Implements: fyne.Focusable
-func (l *List) TypedRune(_ rune) {
-	// intentionally left blank
+func (l *listLayout) stopReorderAnim() {
+	if l.reorderAnim == nil {
+		return
+	}
+	l.reorderAnim.Stop()
+	l.reorderAnim = nil
+	l.renderLock.Lock()
+	removed := append([]*listItem(nil), l.removedRows...)
+	for _, vis := range l.visible {
+		vis.item.animFrom, vis.item.gapShift = 0, 0
+		vis.item.moveToLayout()
+	}
+	l.resizeAnimatedRows(1)
+	for _, vis := range l.visible {
+		vis.item.animHeight = 0
+	}
+	l.renderLock.Unlock()
+	l.releaseRemovedRows(removed)
 }
 
-// Unselect removes the item identified by the given ID from the selection.
-func (l *List) Unselect(id ListItemID) {
-	if len(l.selected) == 0 || l.selected[0] != id {
+func (l *listLayout) startDragGhost(item *listItem) {
+	l.dragGhostOffset = l.list.orientPos(l.drag.pressPos).Y
+	ghost := l.drag.ghostCopy(l.list.CreateItem)
+	if ghost == nil {
 		return
 	}
-
-	l.selected = nil
-	l.Refresh()
-	if f := l.OnUnselected; f != nil {
-		f(id)
+	id := item.id
+	if f := l.list.UpdateItemAsync; f != nil {
+		f(id, ghost, func(apply func()) {
+			if l.draggingRow == id {
+				apply()
+			}
+		})
+	} else {
+		l.list.updateItem(id, ghost)
 	}
+	l.drag.ghost.Resize(item.Size())
+	l.drag.ghost.Show()
 }
 
-// UnselectAll removes all items from the selection.
-//
-// Since: 2.1
-func (l *List) UnselectAll() {
-	if len(l.selected) == 0 {
+func (l *listLayout) updateDragGhost() {
+	if l.drag.ghostItem == nil {
 		return
 	}
+	// keep the ghost clipped to the bounds of the list
+	height := l.list.orient(l.drag.ghost.Size()).Height
+	y := l.dragRelativeY - l.dragGhostOffset
+	if maxY := l.list.orient(l.list.scroller.Size()).Height - height; y > maxY {
+		y = maxY
+	}
+	if y < 0 {
+		y = 0
+	}
+	l.drag.ghost.Resize(l.list.orient(fyne.NewSize(l.list.orient(l.list.Size()).Width, height)))
+	l.drag.ghost.Move(l.list.orientPos(fyne.NewPos(0, y+l.list.orientPos(l.list.scroller.Position()).Y)))
+}
 
-	selected := l.selected
-	l.selected = nil
-	l.Refresh()
-	if f := l.OnUnselected; f != nil {
-		for _, id := range selected {
-			f(id)
-		}
+// how far the remaining distance to its target a row moves each frame while opening a drag gap
+const dragGapAnimStep = 0.25
+
+// returns how far a row should be shifted down to open the drop gap
+func (l *listLayout) dragGapTarget(id ListItemID) float32 {
+	if l.draggingRow < 0 || l.list.DragIndicator.Mode != DragIndicatorGap || id < l.dragInsertAt {
+		return 0
 	}
+	return l.draggedHeight + l.list.rowPadding()
 }
 
-func (l *List) contentMinSize() fyne.Size {
-	l.propertyLock.Lock()
-	defer l.propertyLock.Unlock()
-	if l.Length == nil {
-		return fyne.NewSize(0, 0)
+func (l *listLayout) ensureStartGapAnim() {
+	if l.dragGapAnim != nil {
+		return
 	}
-	items := l.Length()
+	l.dragGapAnim = fyne.NewAnimation(math.MaxInt64 /*until stopped*/, func(_ float32) {
+		l.renderLock.Lock()
+		defer l.renderLock.Unlock()
+		for _, vis := range l.visible {
+			target := l.dragGapTarget(vis.id)
+			shift := vis.item.gapShift
+			if shift == target {
+				continue
+			}
+			shift += (target - shift) * dragGapAnimStep
+			if math.Abs(float64(target-shift)) < 0.5 {
+				shift = target
+			}
+			vis.item.gapShift = shift
+			vis.item.moveToLayout()
+		}
+		l.updateSeparators()
+	})
+	l.dragGapAnim.Start()
+}
 
-	separatorThickness := theme.Padding()
-	if l.itemHeights == nil || len(l.itemHeights) == 0 {
-		return fyne.NewSize(l.itemMin.Width,
-			(l.itemMin.Height+separatorThickness)*float32(items)-separatorThickness)
+func (l *listLayout) closeDragGap() {
+	if l.dragGapAnim == nil {
+		return
+	}
+	l.dragGapAnim.Stop()
+	l.dragGapAnim = nil
+	l.renderLock.Lock()
+	for _, vis := range l.visible {
+		vis.item.gapShift = 0
+		vis.item.moveToLayout()
 	}
+	l.updateSeparators()
+	l.renderLock.Unlock()
+}
 
-	height := float32(0)
-	totalCustom := 0
-	templateHeight := l.itemMin.Height
-	for id, itemHeight := range l.itemHeights {
-		if id < items {
-			totalCustom++
-			height += itemHeight
-		}
+func (l *listLayout) canDragRow(id ListItemID) bool {
+	if !l.list.EnableDragging || l.list.isPlaceholder(id) {
+		return false
 	}
-	height += float32(items-totalCustom) * templateHeight
+	l.list.propertyLock.RLock()
+	pinned := l.list.isPinned(id)
+	l.list.propertyLock.RUnlock()
+	if pinned || l.list.itemLocked(id) {
+		return false
+	}
+	if f := l.list.CanDragItem; f != nil {
+		return f(id)
+	}
+	return true
+}
 
-	return fyne.NewSize(l.itemMin.Width, height+separatorThickness*float32(items-1))
+func (l *listLayout) requiresLongPress() bool {
+	return l.list.EnableDragging && l.list.LongPressToDrag && fyne.CurrentDevice().IsMobile()
 }
 
-func (l *listLayout) calculateDragSeparatorY(thickness float32) float32 {
-	if l.list.scroller.Size().Height <= 0 {
-		return 0
+// returns whether long pressing a row lets it be dragged along the list, see TouchDragConfig.AxisLock
+func (l *listLayout) armsOnLongPress() bool {
+	return l.requiresLongPress() ||
+		(l.list.EnableDragging && l.list.TouchDrag.AxisLock && fyne.CurrentDevice().IsMobile())
+}
+
+// returns whether a drag on the row of a touch device is still within the slop of a press
+// which may become a long press, and otherwise whether it should scroll the list because
+// it starts along the list, see TouchDrag
+func (li *listItem) touchDragIntent(e *fyne.DragEvent) (wait, scroll bool) {
+	l := li.listLayout
+	if !fyne.CurrentDevice().IsMobile() || li.scrolling || li.dragArmed || li.longPressed ||
+		l.draggingRow >= 0 || l.drag.pending || li.grip.Visible() || !l.canDragRow(li.id) {
+		return false, false // grips reorder without a long press, see canStartDrag
+	}
+	cfg := l.list.TouchDrag.withDefaults()
+	d := l.list.orientPos(e.AbsolutePosition.Subtract(li.pressPos))
+	if li.longPressTimer != nil && l.armsOnLongPress() && math.Hypot(float64(d.X), float64(d.Y)) < float64(cfg.Slop) {
+		return true, false
 	}
+	return false, cfg.AxisLock && !l.requiresLongPress() && d.Y*d.Y > d.X*d.X
+}
 
-	relY := l.dragRelativeY
-	if relY < 0 {
-		relY = 0
-	} else if h := l.list.Size().Height; relY > h {
-		relY = h
+// scrolls the list for a frame while a dragged row is near one of its edges
+func (l *listLayout) dragScrollStep(speed float32) {
+	// the pointer may not move while the list does, if it is inside another scroller
+	listPos, _, _ := l.dragViewport()
+	l.dragRelativeY = l.list.orientPos(l.dragPointer).Y - l.list.orientPos(listPos).Y
+	delta := fyne.Delta{DY: -speed}
+	if l.list.horizontal {
+		delta = fyne.Delta{DX: -speed}
 	}
+	l.list.scroller.Scrolled(&fyne.ScrollEvent{Scrolled: delta})
+}
+
+// Declare conformity with WidgetRenderer interface.
+var _ fyne.WidgetRenderer = (*listRenderer)(nil)
+
+type listRenderer struct {
+	objects  []fyne.CanvasObject
+	list     *List
+	scroller *container.Scroll
+	layout   *fyne.Container
+}
+
+func newListRenderer(objects []fyne.CanvasObject, l *List, scroller *container.Scroll, layout *fyne.Container) *listRenderer {
+	lr := &listRenderer{objects: objects, list: l, scroller: scroller, layout: layout}
+	lr.scroller.OnScrolled = l.offsetUpdated
+	return lr
+}
 
-	numItems := 0.0
-	if l.list.Length != nil {
-		numItems = float64(l.list.Length())
+func (l *listRenderer) Layout(size fyne.Size) {
+	layout := l.layout.Layout.(*listLayout)
+	orient, orientPos := l.list.orient, l.list.orientPos
+	size = orient(size)
+	pinnedHeight := orient(layout.pinnedStrip.MinSize()).Height
+	layout.pinnedStrip.Resize(orient(fyne.NewSize(size.Width, pinnedHeight)))
+	scrollSize := orient(fyne.NewSize(size.Width, size.Height-pinnedHeight))
+	l.scroller.Move(orientPos(fyne.NewPos(0, pinnedHeight)))
+	l.scroller.Resize(scrollSize)
+	layout.dragViewFound = time.Time{}
+	layout.emptyState.Move(l.scroller.Position())
+	layout.emptyState.Resize(scrollSize)
+	if style := l.list.ScrollBar; style.isCustom() {
+		width := style.withDefaults().HitWidth
+		l.list.scrollBar.Resize(orient(fyne.NewSize(width, size.Height-pinnedHeight)))
+		l.list.scrollBar.Move(orientPos(fyne.NewPos(size.Width-width, pinnedHeight)))
+		l.list.scrollBar.Refresh()
+	} else {
+		l.list.scrollBar.Hide()
 	}
-	if len(l.list.itemHeights) == 0 {
-		padding := theme.Padding()
-		paddedItemHeight := l.list.itemMin.Height + padding
-		beforeItem := math.Round(float64(relY+l.list.offsetY) / float64(paddedItemHeight))
-		if beforeItem > numItems {
-			beforeItem = numItems
-		}
-		y := float32(beforeItem)*paddedItemHeight - padding/2 - thickness
-		l.dragInsertAt = ListItemID(beforeItem)
-		return y
+	if bar := l.list.indexBar; len(l.list.indexLabels) > 0 {
+		width := orient(bar.MinSize()).Width
+		bar.Resize(orient(fyne.NewSize(width, size.Height)))
+		bar.Move(orientPos(fyne.NewPos(size.Width-width, 0)))
+		bar.Show()
+	} else {
+		bar.Hide()
 	}
-	// TODO: support item heights
-	return 0
 }
 
-// fills l.visibleRowHeights and also returns offY and minRow
-func (l *listLayout) calculateVisibleRowHeights(itemHeight float32, length int) (offY float32, minRow int) {
-	rowOffset := float32(0)
-	isVisible := false
-	l.visibleRowHeights = l.visibleRowHeights[:0]
+func (l *listRenderer) MinSize() fyne.Size {
+	if l.list.ScrollBar.isCustom() {
+		// the scroller does not scroll by itself, so doesn't know it can be smaller than its content
+		min := fyne.NewSize(scrollMinSize, scrollMinSize)
+		if !l.list.ScrollWideRows {
+			min.Width = fyne.Max(min.Width, l.list.contentMinSize().Width)
+		}
+		return l.list.orient(min.Max(l.list.itemMin))
+	}
+	return l.scroller.MinSize().Max(l.list.orient(l.list.itemMin))
+}
 
-	if l.list.scroller.Size().Height <= 0 {
-		return
+func (l *listRenderer) Refresh() {
+	l.list.updateItemMin()
+	l.list.updateHeaderMin()
+	l.list.propertyLock.Lock()
+	if l.list.ItemHeight != nil || l.list.HeightForWidth != nil || l.list.hasSections() {
+		// heights returned by the callbacks or the sections may have changed
+		l.list.heightIndex.valid = false
+		l.list.sectionRows.valid = false
+	}
+	l.list.indexTargets = nil // and so may the keys of the items
+	l.list.propertyLock.Unlock()
+	if l.list.ScrollBar.isCustom() {
+		l.scroller.Direction = container.ScrollNone // hides its scroll bar, but still scrolls
+	} else if l.list.ScrollWideRows {
+		l.scroller.Direction = container.ScrollBoth
+	} else if l.list.horizontal {
+		l.scroller.Direction = container.ScrollHorizontalOnly
+	} else {
+		l.scroller.Direction = container.ScrollVerticalOnly
 	}
+	layout := l.layout.Layout.(*listLayout)
+	layout.updatePinned()
+	l.list.indexBar.Refresh()
+	l.Layout(l.list.Size())
+	l.scroller.Refresh()
+	layout.drag.refreshStyle(l.list.DragIndicator)
+	layout.updateList(false)
+	l.list.refreshScrollBar()
+	canvas.Refresh(l.list)
+}
 
-	// theme.Padding is a slow call, so we cache it
-	padding := theme.Padding()
+func (l *listRenderer) Destroy() {}
 
-	if len(l.list.itemHeights) == 0 {
-		paddedItemHeight := itemHeight + padding
+func (l *listRenderer) Objects() []fyne.CanvasObject {
+	return l.objects
+}
 
-		offY = float32(math.Floor(float64(l.list.offsetY/paddedItemHeight))) * paddedItemHeight
-		minRow = int(math.Floor(float64(offY / paddedItemHeight)))
-		maxRow := int(math.Ceil(float64((offY + l.list.scroller.Size().Height) / paddedItemHeight)))
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*listItem)(nil)
+var _ fyne.Tappable = (*listItem)(nil)
+var _ fyne.SecondaryTappable = (*listItem)(nil)
+var _ desktop.Hoverable = (*listItem)(nil)
+var _ fyne.Draggable = (*listItem)(nil)
+var _ mobile.Touchable = (*listItem)(nil)
+var _ desktop.Mouseable = (*listItem)(nil)
+var _ desktop.Cursorable = (*listItem)(nil)
 
-		if minRow > length-1 {
-			minRow = length - 1
-		}
-		if minRow < 0 {
-			minRow = 0
-			offY = 0
-		}
+// how long a row must be pressed before it is lifted for reordering or OnItemLongPressed is called
+const longPressDuration = 500 * time.Millisecond
 
-		if maxRow > length-1 {
-			maxRow = length - 1
-		}
+type listItem struct {
+	widget.BaseWidget
 
-		for i := 0; i <= maxRow-minRow; i++ {
-			l.visibleRowHeights = append(l.visibleRowHeights, itemHeight)
-		}
-		return
+	id                ListItemID
+	onTapped          func()
+	background        *canvas.Rectangle
+	listLayout        *listLayout
+	child             fyne.CanvasObject
+	leading, trailing fyne.CanvasObject // accessories beside child, if the list creates them
+	skeleton          *canvas.Rectangle // shown instead of child for placeholder rows
+	headerBox         *fyne.Container   // holds the section header shown above the first row of a section
+	content           *fyne.Container
+	skeletonLayout    skeletonLayout
+	hovered, selected bool
+	focused           bool // has the keyboard focus of the list, shown by focusRing
+	focusRing         *canvas.Rectangle
+	flash             *canvas.Rectangle // highlights a match navigated to, see List.NextMatch
+	flashAnim         *fyne.Animation
+	lifted            bool // showing the long-pressed "lifted" state
+	dropInto          bool // a dragged row would be dropped into this row
+	disabled          bool // see List.ItemEnabled
+	disabledOverlay   *canvas.Rectangle
+	rowColor          color.Color // background beneath the highlights, see List.BackgroundForItem
+	rowBackground     *canvas.Rectangle
+	editor            fyne.CanvasObject // shown in place of child while the item is edited
+	editMark          *fyne.Container   // selection circle shown in edit mode, see List.SetEditMode
+	editMarkIcon      *widget.Icon
+	grip              *fyne.Container // drags the row in edit mode, or if ShowReorderGrips is set
+	editing           bool            // showing editMark
+	showGrip          bool
+
+	bindLock sync.Mutex
+	bindGen  uint64 // incremented each time the row is bound to an item
+
+	layoutY    float32 // Y position assigned by the list layout, in the frame of the list
+	gapShift   float32 // offset from layoutY while opening a drag gap or animating rows
+	animFrom   float32 // gapShift at the start of an animation
+	animHeight float32 // full height of a row expanding after an insert or collapsing after a removal, or 0
+
+	longPressTimer *eventTimer
+	longPressed    bool          // long press completed, the tap when it is released is ignored
+	pressPos       fyne.Position // absolute position the row was pressed at
+	dragArmed      bool          // long press completed, drags reorder the list
+	scrolling      bool          // drag is being forwarded to the scroller
+
+	swipeBox     *fyne.Container // buttons of the swipe actions, revealed beside the swiped content
+	swipeLeading bool            // swipeBox holds the leading actions
+	swipeID      ListItemID      // item swipeBox holds the actions of
+	swiping      bool            // drag is swiping the row
+}
+
+func newListItem(child fyne.CanvasObject, listLayout *listLayout, tapped func()) *listItem {
+	li := &listItem{
+		listLayout: listLayout,
+		child:      child,
+		onTapped:   tapped,
+	}
+	if f := listLayout.list.CreateLeadingAccessory; f != nil {
+		li.leading = f()
 	}
+	if f := listLayout.list.CreateTrailingAccessory; f != nil {
+		li.trailing = f()
+	}
+	li.createEditMarks()
+	li.skeleton = canvas.NewRectangle(color.Transparent)
+	li.skeleton.Hide()
+	li.headerBox = container.NewStack()
+	li.headerBox.Hide()
 
-	for i := 0; i < length; i++ {
-		height := itemHeight
-		if h, ok := l.list.itemHeights[i]; ok {
-			height = h
-		}
+	li.ExtendBaseWidget(li)
+	return li
+}
 
-		if rowOffset <= l.list.offsetY-height-padding {
-			// before scroll
-		} else if rowOffset <= l.list.offsetY {
-			minRow = i
-			offY = rowOffset
-			isVisible = true
-		}
-		if rowOffset >= l.list.offsetY+l.list.scroller.Size().Height {
-			break
-		}
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (li *listItem) CreateRenderer() fyne.WidgetRenderer {
+	li.ExtendBaseWidget(li)
 
-		rowOffset += height + padding
-		if isVisible {
-			l.visibleRowHeights = append(l.visibleRowHeights, height)
+	li.background = canvas.NewRectangle(theme.HoverColor())
+	li.background.CornerRadius = theme.SelectionRadiusSize()
+	li.background.Hide()
+	li.focusRing = canvas.NewRectangle(color.Transparent)
+	li.focusRing.Hide()
+	li.flash = canvas.NewRectangle(color.Transparent)
+	li.flash.Hide()
+
+	li.swipeBox = container.NewWithoutLayout()
+	li.swipeBox.Hide()
+
+	li.disabledOverlay = canvas.NewRectangle(disabledOverlayColor())
+	li.disabledOverlay.Hide()
+	li.rowBackground = canvas.NewRectangle(color.Transparent)
+	li.rowBackground.Hide()
+
+	objects := []fyne.CanvasObject{li.swipeBox, li.rowBackground, li.background, li.flash, li.focusRing, li.child}
+	for _, a := range []fyne.CanvasObject{li.leading, li.trailing} {
+		if a != nil {
+			objects = append(objects, a)
 		}
 	}
-	return
+	objects = append(objects, li.editMark, li.grip, container.New(&li.skeletonLayout, li.skeleton), li.disabledOverlay, li.headerBox)
+	li.content = container.New(&listItemLayout{li: li}, objects...)
+	return widget.NewSimpleRenderer(li.content)
 }
 
-const (
-	// max speed (in units per frame) that the list will scroll when dragging above or below
-	maxScrollSpeed = 500
-	minScrollSpeed = 3
-	// how far to drag above or below the top/bottom of the list to reach the max scroll speed
-	scrollAccelerateRange = 250
-)
+// MinSize returns the size that this widget should not shrink below.
+func (li *listItem) MinSize() fyne.Size {
+	li.ExtendBaseWidget(li)
+	return li.BaseWidget.MinSize()
+}
 
-func (l *listLayout) onRowDragged(id ListItemID, e *fyne.DragEvent) {
-	if !l.list.EnableDragging {
+// MouseIn is called when a desktop pointer enters the widget.
+func (li *listItem) MouseIn(e *desktop.MouseEvent) {
+	if li.listLayout.draggingRow >= 0 {
 		return
 	}
-	startedDrag := false
-	if l.draggingRow < 0 /*no drag in progress*/ {
-		l.draggingRow = id
-		startedDrag = true
+	li.hovered = !li.disabled
+	li.Refresh()
+	list := li.listLayout.list
+	if list.isPlaceholder(li.id) {
+		return
 	}
+	if f := list.OnItemHovered; f != nil {
+		f(li.id, true)
+	}
+	li.listLayout.scheduleTooltip(li.id, li.listPosition(e))
+	if !li.disabled {
+		li.listLayout.scheduleHoverSelect(li)
+	}
+}
 
-	listPos := fyne.CurrentApp().Driver().AbsolutePositionForObject(l.list.scroller)
-	// this may break if the list itself is positioned outside the window viewport?
-	// don't worry about it now
-	l.dragRelativeY = e.AbsolutePosition.Y - listPos.Y
-
-	animationSpeedCurve := func(x float32) float32 {
-		// scale to domain: x_: [0, 1]
-		x_ := math.Min(math.Abs(float64(x)), scrollAccelerateRange) / scrollAccelerateRange
-		// quadratic, modified by minScrollSpeed
-		return float32(math.Max(x_*x_*maxScrollSpeed, minScrollSpeed))
+// MouseMoved is called when a desktop pointer hovers over the widget.
+func (li *listItem) MouseMoved(e *desktop.MouseEvent) {
+	if !li.listLayout.tooltip.Visible() {
+		li.listLayout.tooltipPos = li.listPosition(e)
 	}
+}
 
-	// distance from top or bottom of list that starts to trigger scrolling animation
-	scrollStartThreshold := l.list.itemMin.Height / 2
-
-	if topThresh := l.dragRelativeY - scrollStartThreshold; topThresh < 0 {
-		l.scrollAnimSpeed = -animationSpeedCurve(topThresh)
-		l.ensureStartDragAnim()
-	} else if bottmThresh := l.list.Size().Height - scrollStartThreshold; l.dragRelativeY > bottmThresh {
-		l.scrollAnimSpeed = animationSpeedCurve(l.dragRelativeY - bottmThresh)
-		l.ensureStartDragAnim()
-	} else {
-		l.ensureStopDragAnim()
+// MouseOut is called when a desktop pointer exits the widget.
+func (li *listItem) MouseOut() {
+	wasHovered := li.hovered
+	li.hovered = false
+	li.Refresh()
+	li.listLayout.hideTooltip()
+	li.listLayout.stopHoverSelect()
+	list := li.listLayout.list
+	if f := list.OnItemHovered; f != nil && wasHovered && !list.isPlaceholder(li.id) {
+		f(li.id, false)
 	}
+}
 
-	l.updateDragSeparator()
-	if startedDrag && l.list.OnDragBegin != nil {
-		l.list.OnDragBegin(l.draggingRow)
+// starts the timer to select the item of the hovered row, see SelectOnHoverDelay
+func (l *listLayout) scheduleHoverSelect(li *listItem) {
+	l.stopHoverSelect()
+	delay := l.list.SelectOnHoverDelay
+	if delay <= 0 || l.list.editMode {
+		return
 	}
+	id := li.id
+	l.hoverSelectTimer = l.list.afterDelay(delay, func() {
+		// the row may have been scrolled to show another item under the pointer
+		if li.hovered && li.id == id && l.draggingRow < 0 && !l.list.editMode && !l.list.isPlaceholder(id) {
+			l.list.selectID(id, false)
+		}
+	})
 }
 
-func (l *listLayout) onDragEnd() {
-	startRow := l.draggingRow
-	l.ensureStopDragAnim()
-	l.draggingRow = -1
-	l.dragSeparator.Hide()
-	if l.list.OnDragEnd != nil {
-		l.list.OnDragEnd(startRow, l.dragInsertAt)
+func (l *listLayout) stopHoverSelect() {
+	if l.hoverSelectTimer != nil {
+		l.hoverSelectTimer.Stop()
+		l.hoverSelectTimer = nil
 	}
 }
 
-func (l *listLayout) ensureStartDragAnim() {
-	if l.dragScrollAnim == nil {
-		l.dragScrollAnim = fyne.NewAnimation(math.MaxInt64 /*until stopped*/, func(_ float32) {
-			l.list.scroller.Scrolled(&fyne.ScrollEvent{Scrolled: fyne.Delta{DY: -l.scrollAnimSpeed}})
-		})
-		l.dragScrollAnim.Start()
+// Cursor returns the cursor for the item from List.CursorForItem, or the default cursor.
+//
+// Implements: desktop.Cursorable
+func (li *listItem) Cursor() desktop.Cursor {
+	list := li.listLayout.list
+	if f := list.CursorForItem; f != nil && !list.isPlaceholder(li.id) {
+		if c := f(li.id); c != nil {
+			return c
+		}
 	}
+	return desktop.DefaultCursor
 }
 
-func (l *listLayout) ensureStopDragAnim() {
-	if l.dragScrollAnim != nil {
-		l.dragScrollAnim.Stop()
-		l.dragScrollAnim = nil
+// returns the position of the pointer relative to the list
+func (li *listItem) listPosition(e *desktop.MouseEvent) fyne.Position {
+	d := fyne.CurrentApp().Driver()
+	return e.AbsolutePosition.Subtract(d.AbsolutePositionForObject(li.listLayout.list))
+}
+
+// Tapped is called when a pointer tapped event is captured and triggers any tap handler.
+func (li *listItem) Tapped(e *fyne.PointEvent) {
+	list := li.listLayout.list
+	if li.headerBox.Visible() && list.orientPos(e.Position).Y < list.orient(li.headerBox.Size()).Height {
+		list.sectionHeaderTapped(li.id)
+		return
+	}
+	if li.onTapped == nil || li.disabled || li.longPressed {
+		li.longPressed = false
+		return
+	}
+	// double taps are detected here, as implementing fyne.DoubleTappable would delay single taps
+	lo := li.listLayout
+	lo.hideTooltip()
+	if lo.swipedRow >= 0 {
+		lo.animateSwipe(0)
+		return
+	}
+	if list.editMode {
+		list.toggleSelected(li.id)
+		return
+	}
+	now := time.Now()
+	doubleTappable := list.OnItemDoubleTapped != nil || (list.OnItemActivated != nil && !list.ActivateOnSingleTap)
+	if doubleTappable && lo.lastTapID == li.id && now.Sub(lo.lastTapAt) < doubleTapDelay {
+		lo.lastTapAt = time.Time{}
+		if f := list.OnItemDoubleTapped; f != nil {
+			f(li.id)
+		}
+		if f := list.OnItemActivated; f != nil && !list.ActivateOnSingleTap {
+			f(li.id)
+		}
+		return
+	}
+	id := li.id
+	lo.lastTapID, lo.lastTapAt = id, now
+	li.selected = true
+	li.Refresh()
+	li.onTapped()
+	if f := list.OnItemActivated; f != nil && list.ActivateOnSingleTap {
+		f(id)
 	}
 }
 
-// Declare conformity with WidgetRenderer interface.
-var _ fyne.WidgetRenderer = (*listRenderer)(nil)
+// how soon after a tap on a row another tap is a double tap, matching the Fyne drivers
+const doubleTapDelay = 300 * time.Millisecond
 
-type listRenderer struct {
-	objects  []fyne.CanvasObject
-	list     *List
-	scroller *container.Scroll
-	layout   *fyne.Container
+// TappedSecondary is called when a right click or long press is captured on the row
+// and calls OnItemSecondaryTapped and shows the menu for the item, if any.
+func (li *listItem) TappedSecondary(e *fyne.PointEvent) {
+	list := li.listLayout.list
+	if list.isPlaceholder(li.id) || li.disabled ||
+		(li.headerBox.Visible() && list.orientPos(e.Position).Y < list.orient(li.headerBox.Size()).Height) {
+		return
+	}
+	if f := list.OnItemSecondaryTapped; f != nil {
+		f(li.id, e)
+	}
+	if f := list.MenuForItem; f != nil {
+		if menu := f(li.id); menu != nil {
+			if c := fyne.CurrentApp().Driver().CanvasForObject(li); c != nil {
+				widget.ShowPopUpMenuAtPosition(menu, c, e.AbsolutePosition)
+			}
+		}
+	}
 }
 
-func newListRenderer(objects []fyne.CanvasObject, l *List, scroller *container.Scroll, layout *fyne.Container) *listRenderer {
-	lr := &listRenderer{objects: objects, list: l, scroller: scroller, layout: layout}
-	lr.scroller.OnScrolled = l.offsetUpdated
-	return lr
+func (li *listItem) Dragged(e *fyne.DragEvent) {
+	if li.swiping || li.startSwipe(e) {
+		li.swipeDragged(e)
+		return
+	}
+	wait, scroll := li.touchDragIntent(e)
+	if wait {
+		return // the row may still be held long enough to reorder it
+	}
+	if !li.dragArmed {
+		li.cancelLongPress() // the pointer moved, so it is not a long press
+	}
+	// rows capture drags, so pass them to the scroller when they should not reorder
+	if li.scrolling || scroll || (li.listLayout.draggingRow < 0 && !li.canStartDrag(e)) {
+		li.cancelLongPress()
+		li.scrolling = true
+		li.listLayout.list.scroller.Dragged(e)
+		return
+	}
+	li.listLayout.onRowDragged(li, e)
 }
 
-func (l *listRenderer) Layout(size fyne.Size) {
-	l.scroller.Resize(size)
+func (li *listItem) DragEnd() {
+	if li.swiping {
+		li.swipeEnd()
+		return
+	}
+	if li.scrolling {
+		li.scrolling = false
+		li.listLayout.list.scroller.DragEnd()
+		return
+	}
+	li.listLayout.onDragEnd()
+	li.cancelLongPress()
 }
 
-func (l *listRenderer) MinSize() fyne.Size {
-	return l.scroller.MinSize().Max(l.list.itemMin)
+// TouchDown is called when a touch begins on the row.
+//
+// Implements: mobile.Touchable
+func (li *listItem) TouchDown(e *mobile.TouchEvent) {
+	li.startLongPress(e.AbsolutePosition)
 }
 
-func (l *listRenderer) Refresh() {
-	if f := l.list.CreateItem; f != nil {
-		l.list.itemMin = f().MinSize()
+// TouchUp is called when a touch on the row is released.
+//
+// Implements: mobile.Touchable
+func (li *listItem) TouchUp(*mobile.TouchEvent) {
+	if li.listLayout.draggingRow < 0 {
+		li.cancelLongPress()
 	}
-	l.Layout(l.list.Size())
-	l.scroller.Refresh()
-	layout := l.layout.Layout.(*listLayout)
-	layout.dragSeparator.FillColor = theme.ForegroundColor()
-	layout.dragSeparator.Refresh()
-	layout.updateList(false)
-	canvas.Refresh(l.list)
 }
 
-func (l *listRenderer) Destroy() {}
-
-func (l *listRenderer) Objects() []fyne.CanvasObject {
-	return l.objects
+// TouchCancel is called when a touch on the row is interrupted.
+//
+// Implements: mobile.Touchable
+func (li *listItem) TouchCancel(*mobile.TouchEvent) {
+	if li.listLayout.draggingRow < 0 {
+		li.cancelLongPress()
+	}
 }
 
-// Declare conformity with interfaces.
-var _ fyne.Widget = (*listItem)(nil)
-var _ fyne.Tappable = (*listItem)(nil)
-var _ desktop.Hoverable = (*listItem)(nil)
-var _ fyne.Draggable = (*listItem)(nil)
-
-type listItem struct {
-	widget.BaseWidget
-
-	id                ListItemID
-	onTapped          func()
-	background        *canvas.Rectangle
-	listLayout        *listLayout
-	child             fyne.CanvasObject
-	hovered, selected bool
+// MouseDown is called when a mouse button is pressed on the row.
+//
+// Implements: desktop.Mouseable
+func (li *listItem) MouseDown(e *desktop.MouseEvent) {
+	if e.Button == desktop.MouseButtonPrimary {
+		li.startLongPress(e.AbsolutePosition)
+	}
 }
 
-func newListItem(child fyne.CanvasObject, listLayout *listLayout, tapped func()) *listItem {
-	li := &listItem{
-		listLayout: listLayout,
-		child:      child,
-		onTapped:   tapped,
+// MouseUp is called when a mouse button is released on the row.
+//
+// Implements: desktop.Mouseable
+func (li *listItem) MouseUp(*desktop.MouseEvent) {
+	if li.listLayout.draggingRow < 0 {
+		li.cancelLongPress()
 	}
-
-	li.ExtendBaseWidget(li)
-	return li
 }
 
-// CreateRenderer is a private method to Fyne which links this widget to its renderer.
-func (li *listItem) CreateRenderer() fyne.WidgetRenderer {
-	li.ExtendBaseWidget(li)
-
-	li.background = canvas.NewRectangle(theme.HoverColor())
-	li.background.CornerRadius = theme.SelectionRadiusSize()
-	li.background.Hide()
-
-	return widget.NewSimpleRenderer(container.NewStack(
-		li.background, li.child,
-	))
+// starts the timer which lifts the row for reordering, if it requires a long press,
+// and calls OnItemLongPressed
+func (li *listItem) startLongPress(pos fyne.Position) {
+	l := li.listLayout
+	li.longPressed = false
+	li.pressPos = pos
+	arm := l.armsOnLongPress() && l.canDragRow(li.id)
+	onLongPressed := l.list.OnItemLongPressed
+	if l.list.isPlaceholder(li.id) || li.disabled {
+		onLongPressed = nil
+	}
+	if !arm && onLongPressed == nil {
+		return
+	}
+	li.cancelLongPress()
+	id := li.id
+	// the long press is handled on the event goroutine, as the row's drag handlers read its state
+	li.longPressTimer = l.list.afterDelay(longPressDuration, func() {
+		li.longPressed = true
+		if arm {
+			li.dragArmed = true
+			l.liftedRow = id
+			li.lifted = true
+			li.Refresh()
+		}
+		if onLongPressed != nil {
+			onLongPressed(id, pos)
+		}
+	})
 }
 
-// MinSize returns the size that this widget should not shrink below.
-func (li *listItem) MinSize() fyne.Size {
-	li.ExtendBaseWidget(li)
-	return li.BaseWidget.MinSize()
+func (li *listItem) cancelLongPress() {
+	if li.longPressTimer != nil {
+		li.longPressTimer.Stop()
+		li.longPressTimer = nil
+	}
+	li.dragArmed = false
+	if li.listLayout.liftedRow == li.id {
+		li.listLayout.liftedRow = -1
+	}
+	if li.lifted {
+		li.lifted = false
+		li.Refresh()
+	}
 }
 
-// MouseIn is called when a desktop pointer enters the widget.
-func (li *listItem) MouseIn(*desktop.MouseEvent) {
-	if li.listLayout.draggingRow >= 0 {
+// shows the skeleton of a placeholder row in place of the row content, or the content
+func (li *listItem) setPlaceholder(placeholder bool) {
+	if placeholder == li.skeleton.Visible() {
 		return
 	}
-	li.hovered = true
-	li.Refresh()
-}
-
-// MouseMoved is called when a desktop pointer hovers over the widget.
-func (li *listItem) MouseMoved(*desktop.MouseEvent) {
+	if placeholder {
+		// vary the skeleton widths so that the rows look like text
+		li.skeletonLayout.widthRatio = 0.5 + float32((li.id*37)%5)/10
+		li.child.Hide()
+		li.skeleton.Show()
+	} else {
+		li.skeleton.Hide()
+		li.child.Show()
+	}
 }
 
-// MouseOut is called when a desktop pointer exits the widget.
-func (li *listItem) MouseOut() {
-	li.hovered = false
-	li.Refresh()
+// skeletonLayout places the skeleton of a placeholder row like a line of text.
+type skeletonLayout struct {
+	widthRatio float32
 }
 
-// Tapped is called when a pointer tapped event is captured and triggers any tap handler.
-func (li *listItem) Tapped(*fyne.PointEvent) {
-	if li.onTapped != nil {
-		li.selected = true
-		li.Refresh()
-		li.onTapped()
+func (s *skeletonLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	inset := theme.InnerPadding()
+	height := fyne.Min(theme.TextSize(), size.Height-2*theme.Padding())
+	width := (size.Width - 2*inset) * s.widthRatio
+	for _, o := range objects {
+		o.Resize(fyne.NewSize(fyne.Max(width, 0), fyne.Max(height, 0)))
+		o.Move(fyne.NewPos(inset, (size.Height-height)/2))
 	}
 }
 
-func (li *listItem) Dragged(e *fyne.DragEvent) {
-	li.listLayout.onRowDragged(li.id, e)
+func (s *skeletonLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return fyne.NewSize(0, 0)
 }
 
-func (li *listItem) DragEnd() {
-	li.listLayout.onDragEnd()
+// moves the item to its layout position, offset by any animation in progress
+func (li *listItem) moveToLayout() {
+	li.Move(li.listLayout.list.orientPos(fyne.NewPos(0, li.layoutY+li.gapShift)))
 }
 
 func (li *listItem) Refresh() {
+	if li.rowColor != nil {
+		li.rowBackground.FillColor = li.rowColor
+		li.rowBackground.Show()
+	} else {
+		li.rowBackground.Hide()
+	}
+	li.rowBackground.Refresh()
 	li.background.CornerRadius = theme.SelectionRadiusSize()
-	if li.selected {
+	if li.lifted || li.dropInto {
+		li.background.FillColor = theme.PressedColor()
+		li.background.Show()
+	} else if li.selected {
 		li.background.FillColor = theme.SelectionColor()
 		li.background.Show()
 	} else if li.hovered {
@@ -715,6 +3609,15 @@ func (li *listItem) Refresh() {
 		li.background.Hide()
 	}
 	li.background.Refresh()
+	styleFocusRing(li.focusRing, li.focused && !li.listLayout.list.cellFocus)
+	if li.disabled {
+		li.disabledOverlay.FillColor = disabledOverlayColor()
+		li.disabledOverlay.Show()
+	} else {
+		li.disabledOverlay.Hide()
+	}
+	li.disabledOverlay.Refresh()
+	li.refreshEditMarks()
 	canvas.Refresh(li)
 }
 
@@ -729,33 +3632,178 @@ type listItemAndID struct {
 // thickness: theme.SeparatorThicknessSize() * dragSeparatorThicknessMultiplier
 const dragSeparatorThicknessMultiplier = 1.5
 
+// default cap size: drag separator thickness * dragIndicatorCapSizeMultiplier
+const dragIndicatorCapSizeMultiplier = 4
+
+// DragIndicatorMode selects how the list shows where a dragged row will be dropped.
+//
+// Since: Not a core Fyne list API
+type DragIndicatorMode int
+
+const (
+	// DragIndicatorLine draws a line between the rows at the insertion point.
+	//
+	// Since: Not a core Fyne list API
+	DragIndicatorLine DragIndicatorMode = iota
+	// DragIndicatorGap animates the rows apart to open a gap at the insertion point.
+	//
+	// Since: Not a core Fyne list API
+	DragIndicatorGap
+)
+
+// DragFeedbackKind is the drag event that OnDragFeedback is called for.
+//
+// Since: Not a core Fyne list API
+type DragFeedbackKind int
+
+const (
+	// DragFeedbackStart is a row being picked up.
+	//
+	// Since: Not a core Fyne list API
+	DragFeedbackStart DragFeedbackKind = iota
+	// DragFeedbackMove is the position the dragged row would be dropped at changing.
+	//
+	// Since: Not a core Fyne list API
+	DragFeedbackMove
+	// DragFeedbackDrop is the dragged row being dropped.
+	//
+	// Since: Not a core Fyne list API
+	DragFeedbackDrop
+)
+
+// DragIndicatorStyle customizes the insertion indicator shown while dragging rows.
+// Zero-valued fields use the default appearance.
+//
+// Since: Not a core Fyne list API
+type DragIndicatorStyle struct {
+	// Mode selects between the insertion line and opening a gap between rows.
+	// The remaining fields style the line, which is also used in gap mode
+	// when dropping after the last row.
+	Mode DragIndicatorMode
+	// Color of the indicator. Defaults to the theme foreground color.
+	Color color.Color
+	// Thickness of the indicator line.
+	// Defaults to 1.5x the theme separator thickness.
+	Thickness float32
+	// Inset is the horizontal space left empty at each end of the indicator.
+	Inset float32
+	// ShowCaps draws triangles at each end of the line pointing into the list.
+	ShowCaps bool
+	// CapSize is the size of the triangle caps. Defaults to 4x the thickness.
+	CapSize float32
+}
+
+func (s DragIndicatorStyle) withDefaults() DragIndicatorStyle {
+	if s.Color == nil {
+		s.Color = theme.ForegroundColor()
+	}
+	if s.Thickness <= 0 {
+		s.Thickness = theme.SeparatorThicknessSize() * dragSeparatorThicknessMultiplier
+	}
+	if s.CapSize <= 0 {
+		s.CapSize = s.Thickness * dragIndicatorCapSizeMultiplier
+	}
+	return s
+}
+
+// SeparatorStyle customizes the separators drawn between the rows of a list.
+// Zero-valued fields use the default appearance.
+//
+// Since: Not a core Fyne list API
+type SeparatorStyle struct {
+	// Color of the separators. Defaults to the theme separator color.
+	Color color.Color
+	// Thickness of the separators. Defaults to the theme separator thickness.
+	Thickness float32
+	// Inset is the space left empty before the start of each separator,
+	// such as to align them with the text after a leading icon.
+	Inset float32
+	// TrailingInset is the space left empty after the end of each separator.
+	TrailingInset float32
+}
+
+func (s SeparatorStyle) withDefaults() SeparatorStyle {
+	if s.Color == nil {
+		s.Color = theme.SeparatorColor()
+	}
+	if s.Thickness <= 0 {
+		s.Thickness = theme.SeparatorThicknessSize()
+	}
+	return s
+}
+
 type listLayout struct {
-	list          *List
-	separators    []fyne.CanvasObject
-	children      []fyne.CanvasObject
-	dragSeparator canvas.Rectangle
+	list       *List
+	separators []fyne.CanvasObject
+	children   []fyne.CanvasObject
+	drag       reorderDrag // threshold, ghost, indicator and scrolling of a drag to reorder rows
 
-	itemPool          sync.Pool
+	itemPool          listItemPool
+	preallocateAnim   *fyne.Animation // creates the rows of PreallocateItems
 	visible           []listItemAndID
-	slicePool         sync.Pool // *[]itemAndID
+	updating          atomic.Int32 // passes of updateList in progress, see queueMeasuredHeights
+	bufferLock        sync.Mutex
+	buffers           [][]listItemAndID // scratch slices returned by putBuffer, see takeBuffer
 	visibleRowHeights []float32
+	visibleRowIDs     []ListItemID // the items shown by the rows in visibleRowHeights
 	renderLock        sync.RWMutex
-
-	draggingRow     ListItemID // -1 if no drag
-	dragRelativeY   float32    // 0 == top of list widget
-	dragInsertAt    ListItemID
-	dragScrollAnim  *fyne.Animation
-	scrollAnimSpeed float32
+	measuredChanged   bool // protected by list.propertyLock
+
+	draggingRow        ListItemID    // -1 if no drag
+	dragRelativeY      float32       // 0 == top of list widget
+	dragPointer        fyne.Position // absolute position of the pointer at the last drag event
+	dragView           fyne.Position // absolute position of the scroller, cached during a drag, see dragViewport
+	dragViewTop        float32       // top of the part of the scroller inside the window, relative to the scroller
+	dragViewHeight     float32       // height of the part of the scroller inside the window
+	dragViewFound      time.Time     // when dragView was found, or zero to find it again
+	dragInsertAt       ListItemID
+	dragFeedbackAt     ListItemID // dragInsertAt when OnDragFeedback was last called, or -1 until it is found
+	dragInsertMin      ListItemID // the dragged row can only be dropped from dragInsertMin to dragInsertMax
+	dragInsertMax      ListItemID
+	dragSectionMin     ListItemID // the dragged row stays in its section from dragSectionMin to dragSectionMax
+	dragSectionMax     ListItemID
+	dragOutsideSection bool       // the pointer is over a section other than the dragged row's
+	liftedRow          ListItemID // -1 if no row is lifted by a long press
+	draggedHeight      float32
+	dragGapAnim        *fyne.Animation
+	dropTarget         DropTarget // external target the dragged row is over, if any
+	dropInto           ListItemID // row the dragged row would be dropped into, or -1, see List.canDropInto
+	lastTapID          ListItemID // row last tapped, for OnItemDoubleTapped
+	lastTapAt          time.Time
+	tooltip            *fyne.Container // shown over the list by TooltipForItem
+	tooltipBackground  *canvas.Rectangle
+	tooltipLabel       *widget.Label
+	tooltipTimer       *eventTimer
+	hoverSelectTimer   *eventTimer   // selects the hovered row, see SelectOnHoverDelay
+	tooltipPos         fyne.Position // pointer position relative to the list
+	lastLength         int           // rows shown by the last layout, to notice when items are removed
+	anchorID           ListItemID    // item at the top of the visible area at the last layout
+	anchorOffset       float32       // how far the list was scrolled past the top of anchorID
+	reorderAnim        *fyne.Animation
+	removedRows        []*listItem // rows of removed items, collapsing while reorderAnim runs
+	swipedRow          ListItemID  // row swiped open to show its swipe actions, or -1
+	swipeShift         float32     // offset of the swiped row's content across the list
+	swipeAnim          *fyne.Animation
+	editorRow          *listItem       // row showing List.editor, if any
+	shimmerAnim        *fyne.Animation // pulses the placeholder rows
+	shimmerLevel       float32
+	frontierShown      bool // a row of an item still to arrive was set up, see List.LengthEstimate
+
+	emptyState *fyne.Container // centers the list's empty content over the scroller
+
+	pinnedStrip *fyne.Container // rows of the pinned items, above the scroller
+	pinnedRows  []listItemAndID
+
+	dragGhostOffset float32 // pointer Y within the dragged row at drag start
 }
 
 func newListLayout(list *List) fyne.Layout {
-	l := &listLayout{list: list, draggingRow: -1}
-	l.slicePool.New = func() any {
-		s := make([]listItemAndID, 0)
-		return &s
-	}
-	l.dragSeparator.FillColor = theme.ForegroundColor()
-	l.dragSeparator.Hidden = true
+	l := &listLayout{list: list, draggingRow: -1, liftedRow: -1, dropInto: -1, swipedRow: -1}
+	l.drag.init(list.horizontal, l.dragScrollStep)
+	l.createTooltip()
+	l.emptyState = container.NewCenter()
+	l.emptyState.Hide()
+	l.pinnedStrip = container.New(&pinnedLayout{list: list})
 	list.offsetUpdated = l.offsetUpdated
 	return l
 }
@@ -765,7 +3813,16 @@ func (l *listLayout) Layout([]fyne.CanvasObject, fyne.Size) {
 }
 
 func (l *listLayout) MinSize([]fyne.CanvasObject) fyne.Size {
-	return l.list.contentMinSize()
+	return l.list.orient(l.list.contentMinSize())
+}
+
+// returns the width of the rows, which is that of the list unless ScrollWideRows lets them be wider
+func (l *listLayout) rowWidth() float32 {
+	width := l.list.orient(l.list.Size()).Width
+	if l.list.ScrollWideRows && l.list.scroller != nil {
+		width = fyne.Max(width, l.list.orient(l.list.scroller.Content.Size()).Width)
+	}
+	return width
 }
 
 func (l *listLayout) getItem() *listItem {
@@ -775,80 +3832,406 @@ func (l *listLayout) getItem() *listItem {
 			item = newListItem(f(), l, nil)
 		}
 	}
-	return item.(*listItem)
+	return item
 }
 
 func (l *listLayout) offsetUpdated(pos fyne.Position) {
-	if l.list.offsetY == pos.Y {
+	offset := l.list.orientPos(pos).Y
+	if l.list.offsetY == offset {
 		return
 	}
+	l.hideTooltip()
+	l.animateSwipe(0)
 	l.renderLock.Lock()
-	l.list.offsetY = pos.Y
+	l.list.offsetY = offset
 	if l.draggingRow >= 0 {
 		l.updateDragSeparator()
 	}
 	l.renderLock.Unlock()
 	// updateList grabs the renderLock
 	l.updateList(true)
+	l.list.refreshScrollBar()
+	l.list.scheduleSnap()
+	if f := l.list.OnScrolled; f != nil {
+		f(offset)
+	}
+	l.checkReachedEnd(offset)
+}
+
+// notices when the list has fewer rows than at the last layout, dropping the state kept
+// for the items past the new end and keeping the viewport near the item it was showing
+func (l *listLayout) checkLength() {
+	length := l.list.length()
+	shrunk := length < l.lastLength
+	l.lastLength = length
+	if !shrunk || l.list.scroller == nil {
+		return
+	}
+
+	list := l.list
+	if list.placeholders == 0 { // placeholder rows don't replace the state of the data
+		oldToNew := make([]int, length)
+		for id := range oldToNew {
+			oldToNew[id] = id
+		}
+		list.propertyLock.Lock()
+		list.itemHeights = remapByID(list.itemHeights, oldToNew)
+		list.measuredHeights = remapByID(list.measuredHeights, oldToNew)
+		list.collapsedSections = remapByID(list.collapsedSections, oldToNew)
+		list.checks = remapByID(list.checks, oldToNew)
+		list.pinned = remapPinned(list.pinned, oldToNew)
+		list.propertyLock.Unlock()
+
+		selected := list.selected[:0]
+		for _, id := range list.selected {
+			if id < length {
+				selected = append(selected, id)
+			}
+		}
+		list.selected = selected
+		list.updateSelectionBindings()
+	}
+	list.propertyLock.Lock()
+	list.heightIndex.valid = false
+	list.sectionRows.valid = false
+	list.propertyLock.Unlock()
+	if list.currentFocus >= length && length > 0 {
+		list.currentFocus = length - 1
+	}
+
+	y := list.offsetY
+	if l.anchorID < length {
+		list.propertyLock.Lock()
+		y = float32(list.itemOffset(l.anchorID) + float64(l.anchorOffset))
+		list.propertyLock.Unlock()
+	}
+	if max := list.contentMinSize().Height - list.orient(list.scroller.Size()).Height; y > max {
+		y = max
+	}
+	if y < 0 {
+		y = 0
+	}
+	if y != list.offsetY {
+		list.setScrollOffset(y)
+		l.offsetUpdated(list.scroller.Offset)
+	}
+}
+
+// calls OnReachedEnd if the list has been scrolled near its end
+func (l *listLayout) checkReachedEnd(offsetY float32) {
+	f := l.list.OnReachedEnd
+	if f == nil || l.list.scroller == nil || l.list.placeholders > 0 {
+		return
+	}
+	threshold := l.list.ReachedEndDistance + float32(l.list.ReachedEndRows)*(l.list.itemMin.Height+l.list.rowPadding())
+	if l.list.ReachedEndRows == 0 && l.list.ReachedEndDistance == 0 {
+		threshold = l.list.itemMin.Height + l.list.rowPadding()
+	}
+	length := l.list.length()
+	remaining := l.list.contentMinSize().Height - (offsetY + l.list.orient(l.list.scroller.Size()).Height)
+	if remaining > threshold {
+		l.list.reachedEnd = false
+		return
+	}
+	if l.list.reachedEnd && length == l.list.reachedEndLen {
+		return
+	}
+	l.list.reachedEnd = true
+	l.list.reachedEndLen = length
+	f()
 }
 
 func (l *listLayout) setupListItem(li *listItem, id ListItemID, focus bool) {
 	li.id = id
+	li.syncSwipe()
+	li.syncEditor()
+	if l.list.isPlaceholder(id) {
+		l.setupPlaceholder(li)
+		if l.list.pager != nil {
+			l.list.requestPage(id)
+			l.updateShimmer()
+		} else if l.list.placeholders == 0 && l.list.LengthEstimate != nil {
+			l.frontierShown = true
+		}
+		return
+	}
+	li.setPlaceholder(false)
+	l.setupSectionHeader(li, id)
+	disabled := !l.list.itemEnabled(id)
+	var rowColor color.Color
+	if f := l.list.BackgroundForItem; f != nil {
+		rowColor = f(id)
+	}
+	if rowColor == nil && l.list.AlternateRows {
+		l.list.propertyLock.Lock()
+		row, _ := l.list.itemRow(id)
+		l.list.propertyLock.Unlock()
+		if row%2 == 1 {
+			rowColor = l.list.alternateRowColor()
+		}
+	}
 	previousIndicator := li.selected
 	li.selected = false
 	for _, s := range l.list.selected {
 		if id == s {
-			li.selected = true
+			li.selected = !disabled
 			break
 		}
 	}
-	if focus {
-		li.hovered = true
+	lifted := id == l.liftedRow
+	dropInto := id == l.dropInto
+	editing := l.list.editMode
+	showGrip := (editing || l.list.ShowReorderGrips) && l.canDragRow(id)
+	if focus || li.focused || previousIndicator != li.selected || li.hovered || li.lifted != lifted ||
+		li.dropInto != dropInto || li.disabled != disabled || li.rowColor != rowColor ||
+		li.editing != editing || li.showGrip != showGrip {
+		li.hovered, li.focused = false, focus
+		li.lifted, li.dropInto, li.disabled, li.rowColor = lifted, dropInto, disabled, rowColor
+		li.editing, li.showGrip = editing, showGrip
 		li.Refresh()
-	} else if previousIndicator != li.selected || li.hovered {
-		li.hovered = false
+	}
+	li.bindLock.Lock()
+	li.bindGen++
+	gen := li.bindGen
+	li.bindLock.Unlock()
+	if f := l.list.UpdateItemAsync; f != nil {
+		f(id, li.child, func(apply func()) {
+			li.bindLock.Lock()
+			if li.bindGen != gen {
+				li.bindLock.Unlock()
+				return // the row has been recycled
+			}
+			apply()
+			if l.list.AutoSizeItems {
+				l.measureItem(id, li.child)
+			}
+			if l.list.ScrollWideRows {
+				l.measureWidth(li)
+			}
+			li.bindLock.Unlock()
+			if l.list.AutoSizeItems || l.list.ScrollWideRows {
+				l.queueMeasuredHeights()
+			}
+		})
+	} else {
+		l.list.updateItem(id, li.child)
+	}
+	if l.list.AutoSizeItems {
+		l.measureItem(id, li.child)
+	}
+	if f := l.list.UpdateLeadingAccessory; f != nil && li.leading != nil {
+		f(id, li.leading)
+	}
+	if f := l.list.UpdateTrailingAccessory; f != nil && li.trailing != nil {
+		f(id, li.trailing)
+	}
+	if l.list.ScrollWideRows {
+		l.measureWidth(li)
+	}
+	if li.onTapped == nil {
+		// a method value rather than a closure over id, so that scrolling doesn't allocate for every row
+		li.onTapped = li.selectItem
+	}
+}
+
+// focuses the list on the item shown by the row and selects it, when the row is tapped
+func (li *listItem) selectItem() {
+	list := li.listLayout.list
+	if !fyne.CurrentDevice().IsMobile() {
+		canvas := fyne.CurrentApp().Driver().CanvasForObject(list)
+		if canvas != nil {
+			canvas.Focus(list)
+		}
+
+		list.currentFocus = li.id
+	}
+
+	list.Select(li.id)
+}
+
+// shows the row as a placeholder, without binding it to an item
+func (l *listLayout) setupPlaceholder(li *listItem) {
+	li.bindLock.Lock()
+	li.bindGen++ // drop pending async updates
+	li.bindLock.Unlock()
+	li.onTapped = nil
+	l.setupSectionHeader(li, li.id)
+	li.setPlaceholder(true)
+	li.skeleton.CornerRadius = theme.SelectionRadiusSize()
+	li.skeleton.FillColor = skeletonColor(l.shimmerLevel)
+	if li.selected || li.hovered || li.focused || li.lifted || li.disabled || li.rowColor != nil || li.editing || li.showGrip {
+		li.selected, li.hovered, li.focused, li.lifted, li.disabled, li.rowColor = false, false, false, false, false, nil
+		li.editing, li.showGrip = false, false
 		li.Refresh()
 	}
-	if f := l.list.UpdateItem; f != nil {
-		f(id, li.child)
+	li.skeleton.Refresh()
+}
+
+// shows the list's empty content if it has no rows, or hides it
+func (l *listLayout) updateEmptyState() {
+	obj := l.list.emptyContent
+	if obj == nil || l.list.length() > 0 {
+		if l.emptyState.Visible() {
+			l.emptyState.Hide()
+		}
+		return
+	}
+	if len(l.emptyState.Objects) != 1 || l.emptyState.Objects[0] != obj {
+		l.emptyState.Objects = []fyne.CanvasObject{obj}
+		l.emptyState.Refresh()
+	}
+	if !l.emptyState.Visible() {
+		l.emptyState.Show()
+	}
+}
+
+// starts or stops the shimmer animation depending on whether placeholders are shown
+func (l *listLayout) updateShimmer() {
+	if p := l.list.pager; l.list.placeholders == 0 && (p == nil || !p.isLoading()) && !l.awaitingItemsShown() {
+		if l.shimmerAnim != nil {
+			l.shimmerAnim.Stop()
+			l.shimmerAnim = nil
+		}
+		return
+	}
+	if l.shimmerAnim != nil {
+		return
 	}
-	li.onTapped = func() {
-		if !fyne.CurrentDevice().IsMobile() {
-			canvas := fyne.CurrentApp().Driver().CanvasForObject(l.list)
-			if canvas != nil {
-				canvas.Focus(l.list)
+	l.shimmerAnim = fyne.NewAnimation(shimmerDuration, func(f float32) {
+		l.shimmerLevel = f
+		fill := skeletonColor(f)
+		l.renderLock.RLock()
+		for _, vis := range l.visible {
+			if vis.item.skeleton.Visible() {
+				vis.item.skeleton.FillColor = fill
+				vis.item.skeleton.Refresh()
 			}
+		}
+		l.renderLock.RUnlock()
+	})
+	l.shimmerAnim.AutoReverse = true
+	l.shimmerAnim.RepeatCount = fyne.AnimationRepeatForever
+	l.shimmerAnim.Curve = fyne.AnimationEaseInOut
+	l.shimmerAnim.Start()
+}
+
+// how long the placeholder rows take to pulse from dim to bright
+const shimmerDuration = 800 * time.Millisecond
 
-			l.list.currentFocus = id
+// returns the placeholder skeleton color at the given point of the shimmer
+func skeletonColor(level float32) color.Color {
+	c := color.NRGBAModel.Convert(theme.DisabledButtonColor()).(color.NRGBA)
+	c.A = uint8(float32(c.A) * (0.4 + 0.6*level))
+	return c
+}
+
+// the width of the outline around the row with the keyboard focus
+const focusRingWidth = 2
+
+// shows the outline around the row or grid item with the keyboard focus, or hides it
+func styleFocusRing(ring *canvas.Rectangle, focused bool) {
+	if focused {
+		ring.StrokeColor = theme.FocusColor()
+		ring.StrokeWidth = focusRingWidth
+		ring.CornerRadius = theme.SelectionRadiusSize()
+		ring.Show()
+	} else {
+		ring.Hide()
+	}
+	ring.Refresh()
+}
+
+// returns the background color of alternate rows, see AlternateRows
+func (l *List) alternateRowColor() color.Color {
+	if c := l.AlternateRowColor; c != nil {
+		return c
+	}
+	c := color.NRGBAModel.Convert(theme.HoverColor()).(color.NRGBA)
+	c.A /= 2
+	return c
+}
+
+// returns the color of the overlay which dims disabled rows
+func disabledOverlayColor() color.Color {
+	c := color.NRGBAModel.Convert(theme.BackgroundColor()).(color.NRGBA)
+	c.A /= 2
+	return c
+}
+
+// caches the height of an item's content for AutoSizeItems
+func (l *listLayout) measureItem(id ListItemID, child fyne.CanvasObject) {
+	height := l.list.orient(child.MinSize()).Height
+	l.list.propertyLock.Lock()
+	if h, ok := l.list.measuredHeights[id]; !ok || h != height {
+		if l.list.measuredHeights == nil {
+			l.list.measuredHeights = make(map[ListItemID]float32)
 		}
+		oldHeight := l.list.itemHeight(id)
+		l.list.measuredHeights[id] = height
+		l.list.itemHeightChanged(id, oldHeight)
+		l.measuredChanged = true
+	}
+	l.list.propertyLock.Unlock()
+}
 
-		l.list.Select(id)
+// widens the rows to fit the content of a row, for ScrollWideRows
+func (l *listLayout) measureWidth(li *listItem) {
+	width := l.list.orient(li.contentMinSize()).Width
+	l.list.propertyLock.Lock()
+	if width > l.list.widestRow {
+		l.list.widestRow = width
+		l.measuredChanged = true
+	}
+	l.list.propertyLock.Unlock()
+}
+
+// applies the heights measured by an asynchronous row update: by the pass of updateList
+// in progress, if any, when it ends, or else on the event goroutine, in order with the
+// other changes to the layout
+func (l *listLayout) queueMeasuredHeights() {
+	if l.updating.Load() > 0 {
+		return
+	}
+	l.list.runOnEventQueue(l.applyMeasuredHeights)
+}
+
+// lays the list out again if any measured item heights, or the widest row, have changed
+func (l *listLayout) applyMeasuredHeights() {
+	l.list.propertyLock.Lock()
+	changed := l.measuredChanged
+	l.measuredChanged = false
+	l.list.propertyLock.Unlock()
+	if changed {
+		l.list.refreshLayout()
 	}
 }
 
 func (l *listLayout) updateList(newOnly bool) {
+	l.updating.Add(1)
+	l.checkLength()
+	l.updateShimmer()
+	l.updateEmptyState()
 	l.renderLock.Lock()
-	separatorThickness := theme.Padding()
-	width := l.list.Size().Width
-	length := 0
-	if f := l.list.Length; f != nil {
-		length = f()
-	}
-	if l.list.UpdateItem == nil {
+	separatorThickness := l.list.rowPadding()
+	width := l.rowWidth()
+	length := l.list.length()
+	if l.list.UpdateItem == nil && l.list.UpdateItemAsync == nil && l.list.UpdateItemWith == nil {
 		fyne.LogError("Missing UpdateCell callback required for List", nil)
 	}
 
-	// Keep pointer reference for copying slice header when returning to the pool
-	// https://blog.mike.norgate.xyz/unlocking-go-slice-performance-navigating-sync-pool-for-enhanced-efficiency-7cb63b0b453e
-	wasVisiblePtr := l.slicePool.Get().(*[]listItemAndID)
-	wasVisible := (*wasVisiblePtr)[:0]
-	wasVisible = append(wasVisible, l.visible...)
+	wasVisible := append(l.takeBuffer(), l.visible...)
 
 	l.list.propertyLock.Lock()
-	offY, minRow := l.calculateVisibleRowHeights(l.list.itemMin.Height, length)
+	offY, _ := l.calculateVisibleRowHeights(l.list.itemMin.Height, length)
+	if id, ok := l.list.itemAtOffset(float64(l.list.offsetY)); ok {
+		l.anchorID, l.anchorOffset = id, float32(float64(l.list.offsetY)-l.list.itemOffset(id))
+	}
+	rows := l.list.rowCount(length)
 	l.list.propertyLock.Unlock()
-	if len(l.visibleRowHeights) == 0 && length > 0 { // we can't show anything until we have some dimensions
+	if len(l.visibleRowHeights) == 0 && rows > 0 { // we can't show anything until we have some dimensions
 		l.renderLock.Unlock() // user code should not be locked
+		l.putBuffer(wasVisible)
+		l.updating.Add(-1)
 		return
 	}
 
@@ -857,10 +4240,10 @@ func (l *listLayout) updateList(newOnly bool) {
 	oldChildrenLen := len(l.children)
 	l.children = l.children[:0]
 
-	y := offY
+	y := offY + float64(l.list.contentTop())
 	for index, itemHeight := range l.visibleRowHeights {
-		row := index + minRow
-		size := fyne.NewSize(width, itemHeight)
+		row := l.visibleRowIDs[index]
+		size := l.list.orient(fyne.NewSize(width, itemHeight))
 
 		c, ok := l.searchVisible(wasVisible, row)
 		if !ok {
@@ -869,12 +4252,15 @@ func (l *listLayout) updateList(newOnly bool) {
 				continue
 			}
 			c.Resize(size)
+			c.gapShift = l.dragGapTarget(row)
+			c.animFrom, c.animHeight = 0, 0
 		}
 
-		c.Move(fyne.NewPos(0, y))
+		c.layoutY = float32(y)
+		c.moveToLayout()
 		c.Resize(size)
 
-		y += itemHeight + separatorThickness
+		y += float64(itemHeight + separatorThickness)
 		l.visible = append(l.visible, listItemAndID{id: row, item: c})
 		l.children = append(l.children, c)
 	}
@@ -883,7 +4269,10 @@ func (l *listLayout) updateList(newOnly bool) {
 
 	for _, wasVis := range wasVisible {
 		if _, ok := l.searchVisible(l.visible, wasVis.id); !ok {
-			l.itemPool.Put(wasVis.item)
+			l.itemPool.Put(wasVis.item, l.list.MaxPooledItems)
+			if u := l.list.dataUpdater; u != nil {
+				u.rowRecycled(wasVis.id)
+			}
 		}
 	}
 
@@ -892,15 +4281,29 @@ func (l *listLayout) updateList(newOnly bool) {
 	c := l.list.scroller.Content.(*fyne.Container)
 	oldObjLen := len(c.Objects)
 	c.Objects = c.Objects[:0]
+	for _, li := range l.removedRows {
+		c.Objects = append(c.Objects, li) // beneath the rows closing over them
+	}
 	c.Objects = append(c.Objects, l.children...)
 	c.Objects = append(c.Objects, l.separators...)
+	if header := l.list.header; header != nil {
+		header.Move(fyne.NewPos(0, 0))
+		header.Resize(l.list.orient(fyne.NewSize(width, l.list.orient(header.MinSize()).Height)))
+		c.Objects = append(c.Objects, header)
+	}
+	if footer := l.list.footer; footer != nil {
+		l.list.propertyLock.Lock()
+		footerY := l.list.footerOffset(length)
+		l.list.propertyLock.Unlock()
+		footer.Move(l.list.orientPos(fyne.NewPos(0, footerY)))
+		footer.Resize(l.list.orient(fyne.NewSize(width, l.list.orient(footer.MinSize()).Height)))
+		c.Objects = append(c.Objects, footer)
+	}
 	l.nilOldSliceData(c.Objects, len(c.Objects), oldObjLen)
 
 	// make a local deep copy of l.visible since rest of this function is unlocked
 	// and cannot safely access l.visible
-	visiblePtr := l.slicePool.Get().(*[]listItemAndID)
-	visible := (*visiblePtr)[:0]
-	visible = append(visible, l.visible...)
+	visible := append(l.takeBuffer(), l.visible...)
 	l.renderLock.Unlock() // user code should not be locked
 
 	if newOnly {
@@ -915,69 +4318,158 @@ func (l *listLayout) updateList(newOnly bool) {
 		}
 	}
 
-	// nil out all references before returning slices to pool
-	for i := 0; i < len(wasVisible); i++ {
-		wasVisible[i].item = nil
+	l.putBuffer(wasVisible)
+	l.putBuffer(visible)
+
+	l.updating.Add(-1) // before applying, so that heights measured meanwhile aren't missed
+	l.applyMeasuredHeights()
+	if l.frontierShown {
+		l.frontierShown = false
+		l.updateShimmer()
+		l.list.reachedFrontier()
 	}
-	for i := 0; i < len(visible); i++ {
-		visible[i].item = nil
+	// a list whose rows fit in the viewport never scrolls, so it is at its end already
+	if l.list.scroller != nil && l.list.contentMinSize().Height <= l.list.orient(l.list.scroller.Size()).Height {
+		l.checkReachedEnd(l.list.offsetY)
 	}
-	*wasVisiblePtr = wasVisible // Copy the stack header over to the heap
-	*visiblePtr = visible
-	l.slicePool.Put(wasVisiblePtr)
-	l.slicePool.Put(visiblePtr)
 }
 
 func (l *listLayout) updateDragSeparator() {
-	listSize := l.list.Size()
-	thickness := theme.SeparatorThicknessSize() * dragSeparatorThicknessMultiplier
-	l.dragSeparator.Resize(fyne.NewSize(listSize.Width, thickness))
+	orient, orientPos := l.list.orient, l.list.orientPos
+	listSize := orient(l.list.Size())
+	style := l.list.DragIndicator.withDefaults()
+	thickness, height := l.drag.layoutIndicator(style, listSize.Width)
+
 	sepY := l.calculateDragSeparatorY(thickness) - l.list.offsetY
-	padding := theme.Padding()
-	if sepY > listSize.Height+padding || sepY < -padding {
+	padding := l.list.rowPadding()
+	l.updateDropInto()
+	if l.dropTarget != nil || l.dropInto >= 0 || l.dragOutsideSection {
+		l.drag.indicator.Hide()
+		return
+	}
+	if style.Mode == DragIndicatorGap && l.dragInsertAt < l.list.length() {
+		// the gap between rows shows the insertion point
+		l.drag.indicator.Hide()
+		return
+	}
+	if sepY > orient(l.list.scroller.Size()).Height+padding || sepY < -padding {
 		// use margin of [-padding, padding] make sure
 		// it can be shown above/below first and last items
-		l.dragSeparator.Hide()
+		l.drag.indicator.Hide()
+		return
+	}
+	l.drag.indicator.Move(orientPos(fyne.NewPos(0, orientPos(l.list.scroller.Position()).Y+sepY-(height-thickness)/2)))
+	l.drag.indicator.Show()
+}
+
+// works out whether the dragged row would be dropped into the row under the pointer,
+// rather than between rows, if the list allows it
+func (l *listLayout) updateDropInto() {
+	into := ListItemID(-1)
+	if f := l.list.canDropInto; f != nil && l.dropTarget == nil {
+		y := float64(l.dragRelativeY) + float64(l.list.offsetY)
+		l.list.propertyLock.Lock()
+		id, ok := l.list.itemAtOffset(y)
+		top, height := l.list.itemOffset(id), float64(l.list.itemHeight(id))
+		l.list.propertyLock.Unlock()
+		// the middle half of a row drops into it, the rest between rows
+		if ok && id != l.draggingRow && y > top+height/4 && y < top+height*3/4 && f(id) {
+			into = id
+		}
+	}
+	l.setDropInto(into)
+}
+
+// highlights the row that the dragged row would be dropped into, or none if id is -1
+func (l *listLayout) setDropInto(id ListItemID) {
+	if id == l.dropInto {
 		return
 	}
-	l.dragSeparator.Move(fyne.NewPos(0, sepY))
-	l.dragSeparator.Show()
+	old := l.dropInto
+	l.dropInto = id
+	for _, vis := range l.visible {
+		if vis.id == old || vis.id == id {
+			vis.item.dropInto = vis.id == id
+			vis.item.Refresh()
+		}
+	}
 }
 
 func (l *listLayout) updateSeparators() {
 	if l.draggingRow >= 0 {
 		l.updateDragSeparator()
 	}
-	if l.list.HideSeparators {
-		l.separators = nil
+	lenChildren := len(l.children)
+	if l.list.HideSeparators || lenChildren <= 1 {
+		l.separators = l.separators[:0]
 		return
 	}
-	if lenChildren := len(l.children); lenChildren > 1 {
-		if lenSep := len(l.separators); lenSep > lenChildren {
-			l.separators = l.separators[:lenChildren]
-		} else {
-			for i := lenSep; i < lenChildren; i++ {
-				l.separators = append(l.separators, widget.NewSeparator())
-			}
+	// the backing array keeps the separators of earlier frames for reuse
+	if lenChildren > cap(l.separators) {
+		l.separators = append(l.separators[:cap(l.separators)], make([]fyne.CanvasObject, lenChildren-cap(l.separators))...)
+	}
+	l.separators = l.separators[:lenChildren]
+	for i, sep := range l.separators {
+		if sep == nil {
+			l.separators[i] = canvas.NewRectangle(color.Transparent)
 		}
-	} else {
-		l.separators = nil
 	}
 
-	separatorThickness := theme.SeparatorThicknessSize()
-	dividerOff := (theme.Padding() + separatorThickness) / 2
+	style := l.list.Separators.withDefaults()
+	dividerOff := (l.list.rowPadding() + style.Thickness) / 2
+	orient, orientPos := l.list.orient, l.list.orientPos
+	width := fyne.Max(l.rowWidth()-style.Inset-style.TrailingInset, 0)
 	for i, child := range l.children {
 		if i == 0 {
 			continue
 		}
-		l.separators[i].Move(fyne.NewPos(0, child.Position().Y-dividerOff))
-		l.separators[i].Resize(fyne.NewSize(l.list.Size().Width, separatorThickness))
-		l.separators[i].Show()
+		sep := l.separators[i].(*canvas.Rectangle)
+		if sep.FillColor != style.Color {
+			sep.FillColor = style.Color
+			sep.Refresh()
+		}
+		sep.Move(orientPos(fyne.NewPos(style.Inset, orientPos(child.Position()).Y-dividerOff)))
+		sep.Resize(orient(fyne.NewSize(width, style.Thickness)))
+		sep.Show()
+	}
+}
+
+// returns an empty slice for temporary use, reusing the backing array of one returned by putBuffer.
+// Unlike a sync.Pool, the buffers are kept through garbage collections, which happen often while
+// scrolling quickly.
+func (l *listLayout) takeBuffer() []listItemAndID {
+	l.bufferLock.Lock()
+	defer l.bufferLock.Unlock()
+	n := len(l.buffers)
+	if n == 0 {
+		return nil
+	}
+	buf := l.buffers[n-1]
+	l.buffers[n-1] = nil
+	l.buffers = l.buffers[:n-1]
+	return buf
+}
+
+// returns a slice from takeBuffer for reuse, dropping its references to rows
+func (l *listLayout) putBuffer(buf []listItemAndID) {
+	for i := range buf {
+		buf[i].item = nil
 	}
+	l.bufferLock.Lock()
+	l.buffers = append(l.buffers, buf[:0])
+	l.bufferLock.Unlock()
 }
 
-// invariant: visible is in ascending order of IDs
+// invariant: visible is in ascending order of IDs, unless the list is sorted by SetSortOrder
 func (l *listLayout) searchVisible(visible []listItemAndID, id ListItemID) (*listItem, bool) {
+	if l.list.sortLess != nil { // the rows are not in order of their IDs
+		for _, vis := range visible {
+			if vis.id == id {
+				return vis.item, true
+			}
+		}
+		return nil, false
+	}
 	ln := len(visible)
 	idx := sort.Search(ln, func(i int) bool { return visible[i].id >= id })
 	if idx < ln && visible[idx].id == id {
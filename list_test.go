@@ -0,0 +1,293 @@
+package fyneadvancedlist
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+// BenchmarkUpdateListScroll scrolls a 100k-row list a little more than a row at a time,
+// reporting the allocations the list makes to lay out the rows of each frame.
+func BenchmarkUpdateListScroll(b *testing.B) {
+	test.NewApp()
+	defer test.NewApp()
+	list := NewList(
+		func() int { return 100_000 },
+		func() fyne.CanvasObject {
+			r := canvas.NewRectangle(color.Black)
+			r.SetMinSize(fyne.NewSize(100, 20))
+			return r
+		},
+		func(ListItemID, fyne.CanvasObject) {},
+	)
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 600))
+
+	maxOffset := list.contentMinSize().Height - list.Size().Height
+	offset := float32(0)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		offset += 27
+		if offset > maxOffset {
+			offset = 0
+		}
+		list.setScrollOffset(offset)
+		list.offsetUpdated(list.scroller.Offset)
+	}
+}
+
+func TestList_ItemHeightBindingWatchesShownRows(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	data := binding.NewStringList()
+	for i := 0; i < 1000; i++ {
+		_ = data.Append("item")
+	}
+	heights := make(map[ListItemID]binding.Float)
+	list := NewListWithData(data,
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(binding.DataItem, fyne.CanvasObject) {},
+	)
+	list.ItemHeightBinding = func(id ListItemID, _ binding.DataItem) binding.Float {
+		h := binding.NewFloat()
+		_ = h.Set(100)
+		heights[id] = h
+		return h
+	}
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+
+	u := list.dataUpdater
+	u.lock.Lock()
+	bound := len(u.bound)
+	u.lock.Unlock()
+	if bound == 0 || bound > 10 {
+		t.Errorf("%d height bindings watched, want those of the rows shown", bound)
+	}
+	if len(heights) > 20 {
+		t.Errorf("ItemHeightBinding called for %d items, want only those of the rows shown", len(heights))
+	}
+	if h := list.scroller.Content.(*fyne.Container).Layout.(*listLayout).visible[0].item.Size().Height; h != 100 {
+		t.Errorf("first row is %v high, want 100", h)
+	}
+
+	list.ScrollToBottom()
+	u.lock.Lock()
+	_, watched := u.bound[0]
+	u.lock.Unlock()
+	if watched {
+		t.Error("height binding of a recycled row still watched")
+	}
+}
+
+func TestList_BoundItemsUnwatchedWhenRecycled(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	data := binding.NewStringList()
+	for i := 0; i < 1000; i++ {
+		_ = data.Append("item")
+	}
+	list := NewListWithData(data,
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(binding.DataItem, fyne.CanvasObject) {},
+	)
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+
+	for id := 0; id < 1000; id += 10 {
+		list.ScrollTo(id)
+	}
+	u := list.dataUpdater
+	u.lock.Lock()
+	watched := len(u.items)
+	_, first := u.items[0]
+	u.lock.Unlock()
+	if shown := len(list.scroller.Content.(*fyne.Container).Layout.(*listLayout).visible); watched != shown {
+		t.Errorf("%d items watched, want the %d shown", watched, shown)
+	}
+	if first {
+		t.Error("item of a recycled row still watched")
+	}
+}
+
+func TestList_AnimateChangesReleasesRemovedRows(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	length := 20
+	list := NewList(
+		func() int { return length },
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(ListItemID, fyne.CanvasObject) {},
+	)
+	list.AnimateChanges = true
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+	lo := list.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	rowHeight := lo.visible[0].item.Size().Height
+
+	length--
+	list.ApplyDiff(ListDiff{Removed: []ListItemID{1}})
+	length++
+	list.ApplyDiff(ListDiff{Inserted: []ListItemID{3}})
+
+	if n := len(lo.removedRows); n != 0 {
+		t.Errorf("%d removed rows held after the animation, want none", n)
+	}
+	rows := 0
+	for _, o := range list.scroller.Content.(*fyne.Container).Objects {
+		if _, ok := o.(*listItem); ok {
+			rows++
+		}
+	}
+	if rows != len(lo.visible) {
+		t.Errorf("%d rows in the content, want the %d visible", rows, len(lo.visible))
+	}
+	for _, vis := range lo.visible {
+		if h := vis.item.Size().Height; h != rowHeight || vis.item.animHeight != 0 {
+			t.Errorf("row of item %d is %v high, animating to %v, want %v", vis.id, h, vis.item.animHeight, rowHeight)
+		}
+	}
+}
+
+type testAdapter []int
+
+func (a testAdapter) Len() int { return len(a) }
+
+func (a testAdapter) Move(from, to int) {
+	item := a[from]
+	copy(a[from:], a[from+1:])
+	copy(a[to+1:], a[to:len(a)-1])
+	a[to] = item
+}
+
+func TestList_MoveItemKeepsCheckStates(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	list := NewReorderableList(make(testAdapter, 10),
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(ListItemID, fyne.CanvasObject) {},
+	)
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+
+	list.SetItemCheckState(1, CheckChecked)
+	list.SetItemCheckState(4, CheckChecked)
+	list.moveItem(1, 6) // to index 5, moving items 2 to 5 up
+	for id, want := range map[ListItemID]CheckState{1: CheckUnchecked, 3: CheckChecked, 4: CheckUnchecked, 5: CheckChecked} {
+		if got := list.ItemCheckState(id); got != want {
+			t.Errorf("item %d has check state %v, want %v", id, got, want)
+		}
+	}
+}
+
+func TestList_ReachedEndWhenRowsFit(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	length := 3
+	list := NewList(
+		func() int { return length },
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(ListItemID, fyne.CanvasObject) {},
+	)
+	reached := 0
+	list.OnReachedEnd = func() {
+		reached++
+		if length < 6 {
+			length++
+			list.Refresh()
+		}
+	}
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 600))
+
+	if length != 6 {
+		t.Errorf("list has %d items, want OnReachedEnd to load more until it has 6", length)
+	}
+	calls := reached
+	list.Refresh()
+	if reached != calls {
+		t.Error("OnReachedEnd called again without the list changing")
+	}
+}
+
+func TestList_RowPositionsWith10MRows(t *testing.T) {
+	const length = 10_000_000
+	for _, tt := range []struct {
+		name   string
+		height func(ListItemID) float32 // nil for the template height of 20
+	}{
+		{name: "uniform"},
+		{name: "variable", height: func(id ListItemID) float32 { return float32(20 + id%3*10) }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			test.NewApp()
+			defer test.NewApp()
+			list := NewList(
+				func() int { return length },
+				func() fyne.CanvasObject {
+					r := canvas.NewRectangle(color.Black)
+					r.SetMinSize(fyne.NewSize(100, 20))
+					return r
+				},
+				func(ListItemID, fyne.CanvasObject) {},
+			)
+			list.RowPadding = 4
+			list.ItemHeight = tt.height
+			w := test.NewWindow(list)
+			defer w.Close()
+			w.Resize(fyne.NewSize(200, 400))
+
+			// the exact offset of the top of the row, summing the padded heights before it
+			offset := func(id ListItemID) float64 {
+				if tt.height == nil {
+					return float64(id) * 24
+				}
+				y := float64(id/3) * (24 + 34 + 44)
+				for k := 0; k < id%3; k++ {
+					y += float64(24 + k*10)
+				}
+				return y
+			}
+			lo := list.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+			for _, id := range []ListItemID{5_000_001, 7_654_321, length - 20} {
+				list.propertyLock.Lock()
+				got := list.itemOffset(id)
+				at, _ := list.itemAtOffset(offset(id) + 1)
+				before, _ := list.itemAtOffset(offset(id) - 1)
+				list.propertyLock.Unlock()
+				if got != offset(id) {
+					t.Errorf("item %d is at %v, want %v", id, got, offset(id))
+				}
+				if at != id || before != id-1 {
+					t.Errorf("items at %v and %v are %d and %d, want %d and %d", offset(id)+1, offset(id)-1, at, before, id, id-1)
+				}
+
+				list.ScrollTo(id)
+				shown := false
+				for _, vis := range lo.visible {
+					if vis.id == id {
+						shown = true
+						if want := float32(offset(id)); vis.item.layoutY != want {
+							t.Errorf("row of item %d laid out at %v, want %v", id, vis.item.layoutY, want)
+						}
+					}
+				}
+				if !shown {
+					t.Errorf("item %d not shown after scrolling to it", id)
+				}
+			}
+		})
+	}
+}
@@ -16,35 +16,19 @@ func main() {
 	a := app.NewWithID("test")
 	w := a.NewWindow("win")
 
-	data := []string{}
+	data := rows{}
 	for i := 0; i < 1000; i++ {
 		data = append(data, fmt.Sprintf("Test list row %d", i))
 	}
 
-	l := fyneadvancedlist.NewList(
-		func() int { return len(data) },
+	l := fyneadvancedlist.NewReorderableList(
+		&data,
 		func() fyne.CanvasObject { return widget.NewLabel("") },
 		func(lii fyneadvancedlist.ListItemID, co fyne.CanvasObject) {
 			co.(*widget.Label).SetText(data[lii])
 		},
 	)
-	var selected fyneadvancedlist.ListItemID
-	l.OnSelected = func(id fyneadvancedlist.ListItemID) { selected = id }
-	l.EnableDragging = true
 	l.OnDragBegin = l.Select
-	l.OnDragEnd = func(_, insertAt fyneadvancedlist.ListItemID) {
-		newData := make([]string, 0, len(data))
-		newData = append(newData, data[:insertAt]...)
-		newData = append(newData, data[selected])
-		for i := insertAt; i < len(data); i++ {
-			if i != selected {
-				newData = append(newData, data[i])
-			}
-		}
-		data = newData
-		l.UnselectAll()
-		l.Refresh()
-	}
 
 	w.SetContent(container.NewBorder(
 		container.NewStack(
@@ -56,3 +40,18 @@ func main() {
 	w.Resize(fyne.NewSize(300, 400))
 	w.ShowAndRun()
 }
+
+type rows []string
+
+func (r *rows) Len() int { return len(*r) }
+
+func (r *rows) Move(from, to int) {
+	s := *r
+	item := s[from]
+	if from < to {
+		copy(s[from:to], s[from+1:to+1])
+	} else {
+		copy(s[to+1:from+1], s[to:from])
+	}
+	s[to] = item
+}
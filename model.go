@@ -0,0 +1,235 @@
+package fyneadvancedlist
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+// ListModel is a slice-backed data model for lists. Lists created with
+// NewListWithModel are notified of each change with the smallest update needed,
+// so rows animate, and keep their selection and scroll position, without the
+// app writing Length and UpdateItem callbacks or calling Refresh.
+//
+// Since: Not a core Fyne list API
+type ListModel[T any] struct {
+	lock  sync.RWMutex
+	items []T
+	lists []*List
+}
+
+// Declare conformity with interfaces.
+var _ ReorderableAdapter = (*ListModel[int])(nil)
+
+// NewListModel creates a model holding the given items.
+// The model takes ownership of the slice.
+//
+// Since: Not a core Fyne list API
+func NewListModel[T any](items []T) *ListModel[T] {
+	return &ListModel[T]{items: items}
+}
+
+// NewListWithModel creates a list widget that displays the items of model.
+// If EnableDragging is set on the list, dropped rows are moved within the model.
+//
+// Since: Not a core Fyne list API
+func NewListWithModel[T any](model *ListModel[T], createItem func() fyne.CanvasObject, updateItem func(T, fyne.CanvasObject)) *List {
+	list := NewList(model.Len, createItem, func(id ListItemID, o fyne.CanvasObject) {
+		model.lock.RLock()
+		if id >= len(model.items) {
+			model.lock.RUnlock()
+			return
+		}
+		item := model.items[id]
+		model.lock.RUnlock()
+		updateItem(item, o)
+	})
+	list.reorderAdapter = model
+
+	model.lock.Lock()
+	model.lists = append(model.lists, list)
+	model.lock.Unlock()
+	return list
+}
+
+// NewTypedList creates a list widget that displays the items returned by items, in rows
+// of the type returned by create, so that update is passed the item and its row without
+// indexing a slice or asserting the type of the row. items is called whenever the list
+// needs its items, so it should return the current slice without copying it.
+//
+// Since: Not a core Fyne list API
+func NewTypedList[T any, W fyne.CanvasObject](items func() []T, create func() W, update func(T, W)) *List {
+	return NewList(func() int {
+		return len(items())
+	}, func() fyne.CanvasObject {
+		return create()
+	}, func(id ListItemID, o fyne.CanvasObject) {
+		if all := items(); id < len(all) {
+			update(all[id], o.(W))
+		}
+	})
+}
+
+// Len returns the number of items in the model.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Len() int {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return len(m.items)
+}
+
+// Get returns the item at index i.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Get(i int) T {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.items[i]
+}
+
+// Items returns a copy of all items in the model.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Items() []T {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return append([]T(nil), m.items...)
+}
+
+// Set replaces the item at index i, refreshing only its row.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Set(i int, item T) {
+	m.lock.Lock()
+	m.items[i] = item
+	m.lock.Unlock()
+	m.notify(ListDiff{Changed: []ListItemID{i}})
+}
+
+// SetAll replaces all items in the model and refreshes the attached lists.
+// The model takes ownership of the slice.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) SetAll(items []T) {
+	m.lock.Lock()
+	m.items = items
+	lists := append([]*List(nil), m.lists...)
+	m.lock.Unlock()
+	for _, l := range lists {
+		l.Refresh()
+	}
+}
+
+// Append adds items to the end of the model.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Append(items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	m.lock.Lock()
+	i := len(m.items)
+	m.insert(i, items)
+	m.lock.Unlock()
+	m.notifyInserted(i, len(items))
+}
+
+// Insert adds items to the model so that the first of them is at index i.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Insert(i int, items ...T) {
+	if len(items) == 0 {
+		return
+	}
+	m.lock.Lock()
+	m.insert(i, items)
+	m.lock.Unlock()
+	m.notifyInserted(i, len(items))
+}
+
+// insert adds items at index i. The caller must hold the write lock.
+func (m *ListModel[T]) insert(i int, items []T) {
+	m.items = append(m.items, items...) // grow
+	copy(m.items[i+len(items):], m.items[i:])
+	copy(m.items[i:], items)
+}
+
+func (m *ListModel[T]) notifyInserted(i, n int) {
+	inserted := make([]ListItemID, n)
+	for j := range inserted {
+		inserted[j] = i + j
+	}
+	m.notify(ListDiff{Inserted: inserted})
+}
+
+// Remove removes the item at index i.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Remove(i int) {
+	m.lock.Lock()
+	var zero T
+	copy(m.items[i:], m.items[i+1:])
+	m.items[len(m.items)-1] = zero // release the reference
+	m.items = m.items[:len(m.items)-1]
+	m.lock.Unlock()
+	m.notify(ListDiff{Removed: []ListItemID{i}})
+}
+
+// Move moves the item at index from so that it ends up at index to.
+//
+// Implements: ReorderableAdapter
+func (m *ListModel[T]) Move(from, to int) {
+	if from == to {
+		return
+	}
+	m.lock.Lock()
+	item := m.items[from]
+	if from < to {
+		copy(m.items[from:to], m.items[from+1:to+1])
+	} else {
+		copy(m.items[to+1:from+1], m.items[to:from])
+	}
+	m.items[to] = item
+	m.lock.Unlock()
+	m.notify(ListDiff{Moved: []ListMove{{From: from, To: to}}})
+}
+
+// Detach stops the model from updating a list created for it with NewListWithModel,
+// so that the model no longer keeps the list alive. The list still shows the items of the
+// model, but must be refreshed by the app when they change.
+//
+// Since: Not a core Fyne list API
+func (m *ListModel[T]) Detach(l *List) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i, list := range m.lists {
+		if list == l {
+			copy(m.lists[i:], m.lists[i+1:])
+			m.lists[len(m.lists)-1] = nil // release the reference
+			m.lists = m.lists[:len(m.lists)-1]
+			return
+		}
+	}
+}
+
+// notifiesList returns whether the model updates the list itself when changed.
+func (m *ListModel[T]) notifiesList(l *List) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	for _, list := range m.lists {
+		if list == l {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *ListModel[T]) notify(diff ListDiff) {
+	m.lock.RLock()
+	lists := append([]*List(nil), m.lists...)
+	m.lock.RUnlock()
+	for _, l := range lists {
+		l.ApplyDiff(diff)
+	}
+}
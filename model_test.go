@@ -0,0 +1,85 @@
+package fyneadvancedlist
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func TestListModel_Changes(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		change func(m *ListModel[int])
+		want   []int
+	}{
+		{name: "insert at start", change: func(m *ListModel[int]) { m.Insert(0, 10, 11) }, want: []int{10, 11, 0, 1, 2, 3}},
+		{name: "insert in middle", change: func(m *ListModel[int]) { m.Insert(2, 10) }, want: []int{0, 1, 10, 2, 3}},
+		{name: "insert at end", change: func(m *ListModel[int]) { m.Insert(4, 10) }, want: []int{0, 1, 2, 3, 10}},
+		{name: "insert nothing", change: func(m *ListModel[int]) { m.Insert(1) }, want: []int{0, 1, 2, 3}},
+		{name: "append", change: func(m *ListModel[int]) { m.Append(10, 11) }, want: []int{0, 1, 2, 3, 10, 11}},
+		{name: "remove first", change: func(m *ListModel[int]) { m.Remove(0) }, want: []int{1, 2, 3}},
+		{name: "remove last", change: func(m *ListModel[int]) { m.Remove(3) }, want: []int{0, 1, 2}},
+		{name: "move down", change: func(m *ListModel[int]) { m.Move(0, 2) }, want: []int{1, 2, 0, 3}},
+		{name: "move up", change: func(m *ListModel[int]) { m.Move(3, 1) }, want: []int{0, 3, 1, 2}},
+		{name: "move in place", change: func(m *ListModel[int]) { m.Move(2, 2) }, want: []int{0, 1, 2, 3}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewListModel([]int{0, 1, 2, 3})
+			tt.change(m)
+			if got := m.Items(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("items = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListModel_ConcurrentAppend(t *testing.T) {
+	m := NewListModel[int](nil)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.Append(i)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if n := m.Len(); n != 800 {
+		t.Errorf("%d items after concurrent appends, want 800", n)
+	}
+}
+
+func TestListModel_Detach(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	m := NewListModel([]string{"a", "b", "c"})
+	list := NewListWithModel(m,
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(item string, o fyne.CanvasObject) { o.(*widget.Label).SetText(item) },
+	)
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+
+	if !m.notifiesList(list) {
+		t.Fatal("model does not notify the list created for it")
+	}
+	m.Detach(list)
+	if m.notifiesList(list) {
+		t.Error("model still notifies a detached list")
+	}
+	if len(m.lists) != 0 {
+		t.Errorf("model keeps %d lists after detaching its only one", len(m.lists))
+	}
+	m.Append("d") // must not update the detached list
+	list.Refresh()
+	if n := list.Length(); n != 4 {
+		t.Errorf("detached list has %d items, want the 4 of the model", n)
+	}
+}
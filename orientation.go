@@ -0,0 +1,47 @@
+package fyneadvancedlist
+
+import "fyne.io/fyne/v2"
+
+// NewHorizontalList creates and returns a list widget for displaying items from left to right
+// in a horizontal scroller, such as a strip of thumbnails. Everything that refers to the height
+// or Y position of rows, such as SetItemHeight, ItemPosition and the scroll offset, refers to
+// their width or X position instead, and HeightForWidth is passed the height of the list.
+// The Left and Right keys move the focus between items.
+//
+// Since: Not a core Fyne list API
+func NewHorizontalList(length func() int, createItem func() fyne.CanvasObject, updateItem func(ListItemID, fyne.CanvasObject)) *List {
+	list := &List{Length: length, CreateItem: createItem, UpdateItem: updateItem, horizontal: true}
+	list.ExtendBaseWidget(list)
+	return list
+}
+
+// The list lays its rows out in a vertical frame, where Y runs in the direction it scrolls.
+// orient converts a size between that frame and the canvas, swapping the axes of horizontal lists.
+func (l *List) orient(s fyne.Size) fyne.Size {
+	if l.horizontal {
+		return fyne.NewSize(s.Height, s.Width)
+	}
+	return s
+}
+
+// orientPos converts a position between the frame of the list and the canvas, like orient.
+func (l *List) orientPos(p fyne.Position) fyne.Position {
+	if l.horizontal {
+		return fyne.NewPos(p.Y, p.X)
+	}
+	return p
+}
+
+// returns the offset of the scroller in the direction the list scrolls
+func (l *List) scrollOffset() float32 {
+	return l.orientPos(l.scroller.Offset).Y
+}
+
+// sets the offset of the scroller in the direction the list scrolls
+func (l *List) setScrollOffset(offset float32) {
+	if l.horizontal {
+		l.scroller.Offset.X = offset
+	} else {
+		l.scroller.Offset.Y = offset
+	}
+}
@@ -0,0 +1,179 @@
+package fyneadvancedlist
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// DefaultPageSize is the number of items requested at a time by SetPageProvider
+// if the list PageSize is not set.
+//
+// Since: Not a core Fyne list API
+const DefaultPageSize = 50
+
+// how long to wait before fetching a page again after it failed or came back short
+const pageRetryDelay = time.Second
+
+// pageProvider loads the items of a paged list on demand.
+type pageProvider struct {
+	lock     sync.Mutex
+	fetch    func(offset, limit int) (int, error)
+	pageSize int
+	total    int               // -1 if unknown
+	loaded   map[int]int       // number of items loaded for each page
+	loading  map[int]bool      // pages being fetched
+	retryAt  map[int]time.Time // pages that failed or loaded too few items, and when to fetch them again
+	ended    bool              // a fetch returned fewer items than requested, when the total is unknown
+}
+
+// SetPageProvider makes the list load its items a page at a time as they are scrolled into view.
+// fetch is called on a background goroutine to load limit items starting at offset,
+// and returns the number of items it loaded. The list then calls UpdateItem for them.
+// If total is negative the number of items is unknown, so pages are loaded in order
+// and the list ends when fetch loads fewer items than requested.
+// If fetch returns an error, or loads fewer items than requested when the total is known,
+// the page is fetched again a second later while its rows are shown.
+// Rows that are not loaded yet are shown as shimmering placeholder rows.
+// Length is not used while a page provider is set; pass a nil fetch to remove it.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetPageProvider(total int, fetch func(offset, limit int) (int, error)) {
+	var p *pageProvider
+	if fetch != nil {
+		size := l.PageSize
+		if size <= 0 {
+			size = DefaultPageSize
+		}
+		p = &pageProvider{fetch: fetch, pageSize: size, total: total,
+			loaded: make(map[int]int), loading: make(map[int]bool), retryAt: make(map[int]time.Time)}
+	}
+	l.propertyLock.Lock()
+	l.pager = p
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// length returns the number of rows, including the loading row if the total is unknown.
+func (p *pageProvider) length() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.total >= 0 {
+		return p.total
+	}
+	count := 0
+	for page := 0; ; page++ {
+		n, ok := p.loaded[page]
+		if !ok {
+			break
+		}
+		count += n
+	}
+	if p.ended {
+		return count
+	}
+	return count + 1
+}
+
+// isLoaded returns whether the item with the given ID has been fetched.
+func (p *pageProvider) isLoaded(id ListItemID) bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	page := id / p.pageSize
+	n, ok := p.loaded[page]
+	return ok && id-page*p.pageSize < n
+}
+
+// isLoading returns whether any page is being fetched.
+func (p *pageProvider) isLoading() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.loading) > 0
+}
+
+// request starts fetching the page containing id, if it is not loaded or loading,
+// and calls done once the fetch has returned, with retry set if the page must be fetched again.
+func (p *pageProvider) request(id ListItemID, done func(retry bool)) {
+	page := id / p.pageSize
+	p.lock.Lock()
+	_, loaded := p.loaded[page]
+	retryAt, retry := p.retryAt[page]
+	if (loaded && !retry) || p.loading[page] || (p.total < 0 && len(p.loaded) != page) ||
+		(retry && time.Now().Before(retryAt)) {
+		// pages of unknown lists are fetched in order
+		p.lock.Unlock()
+		return
+	}
+	p.loading[page] = true
+	p.lock.Unlock()
+
+	go func() {
+		offset := page * p.pageSize
+		limit := p.pageSize
+		if p.total >= 0 && offset+limit > p.total {
+			limit = p.total - offset
+		}
+		n, err := p.fetch(offset, limit)
+		if err != nil {
+			fyne.LogError(fmt.Sprintf("Error fetching list items %d to %d", offset, offset+limit-1), err)
+		}
+
+		p.lock.Lock()
+		delete(p.loading, page)
+		retry := err != nil || (n < limit && p.total >= 0)
+		if err == nil {
+			p.loaded[page] = n
+			if n < limit && p.total < 0 {
+				p.ended = true
+			}
+		}
+		if retry {
+			p.retryAt[page] = time.Now().Add(pageRetryDelay)
+		} else {
+			delete(p.retryAt, page)
+		}
+		p.lock.Unlock()
+		done(retry)
+	}()
+}
+
+// returns whether the list is waiting for items to arrive, see LengthEstimate
+func (l *List) awaitingItems() bool {
+	if l.LengthEstimate == nil || l.Length == nil || l.pager != nil {
+		return false
+	}
+	return l.dataLength() > l.Length()
+}
+
+// calls OnFrontierReached, once for each number of items that have arrived
+func (l *List) reachedFrontier() {
+	f := l.OnFrontierReached
+	if f == nil || l.Length == nil {
+		return
+	}
+	arrived := l.Length()
+	if l.frontierCalled && arrived == l.frontierLen {
+		return
+	}
+	l.frontierCalled, l.frontierLen = true, arrived
+	f(arrived)
+}
+
+// returns whether rows of items still to arrive are shown, see List.LengthEstimate
+func (l *listLayout) awaitingItemsShown() bool {
+	if !l.list.awaitingItems() {
+		return false
+	}
+	arrived := l.list.Length()
+	l.renderLock.RLock()
+	defer l.renderLock.RUnlock()
+	for _, id := range l.visibleRowIDs {
+		if id >= arrived {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package fyneadvancedlist
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// requests the page containing id and waits for the fetch to return, returning whether it must be retried
+func requestAndWait(t *testing.T, p *pageProvider, id ListItemID) (retry bool) {
+	t.Helper()
+	done := make(chan bool, 1)
+	p.request(id, func(retry bool) { done <- retry })
+	select {
+	case retry = <-done:
+		return retry
+	case <-time.After(time.Second):
+		t.Fatalf("page of item %d was not fetched", id)
+		return false
+	}
+}
+
+func newTestPageProvider(total int, fetch func(offset, limit int) (int, error)) *pageProvider {
+	return &pageProvider{fetch: fetch, pageSize: 10, total: total,
+		loaded: make(map[int]int), loading: make(map[int]bool), retryAt: make(map[int]time.Time)}
+}
+
+func TestPageProvider_ShortPageRetried(t *testing.T) {
+	fetches := 0
+	p := newTestPageProvider(25, func(offset, limit int) (int, error) {
+		fetches++
+		if fetches == 1 {
+			return limit / 2, nil
+		}
+		return limit, nil
+	})
+
+	if !requestAndWait(t, p, 12) {
+		t.Error("short page not marked to be retried")
+	}
+	if !p.isLoaded(14) || p.isLoaded(15) {
+		t.Error("items of the short page not loaded as fetched")
+	}
+	p.request(12, func(bool) { t.Error("page fetched again before the retry delay") })
+
+	p.retryAt[1] = time.Now()
+	if requestAndWait(t, p, 15) {
+		t.Error("full page marked to be retried")
+	}
+	if !p.isLoaded(19) {
+		t.Error("items of the retried page not loaded")
+	}
+	p.request(15, func(bool) { t.Error("loaded page fetched again") })
+	if fetches != 2 {
+		t.Errorf("page fetched %d times, want 2", fetches)
+	}
+}
+
+func TestPageProvider_FailedPageRetried(t *testing.T) {
+	fail := true
+	p := newTestPageProvider(-1, func(offset, limit int) (int, error) {
+		if fail {
+			return 0, errors.New("offline")
+		}
+		return limit, nil
+	})
+
+	if !requestAndWait(t, p, 0) {
+		t.Error("failed page not marked to be retried")
+	}
+	if p.isLoaded(0) || p.ended || p.length() != 1 {
+		t.Error("failed page treated as loaded")
+	}
+
+	fail = false
+	p.retryAt[0] = time.Now()
+	requestAndWait(t, p, 0)
+	if !p.isLoaded(9) || p.length() != 11 {
+		t.Error("retried page not loaded")
+	}
+}
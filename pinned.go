@@ -0,0 +1,167 @@
+package fyneadvancedlist
+
+import (
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// PinItem moves the item into a strip at the top of the list that does not scroll,
+// such as for a favorite or "now playing" item. Pinned items are shown in order of their IDs.
+//
+// Since: Not a core Fyne list API
+func (l *List) PinItem(id ListItemID) {
+	l.propertyLock.Lock()
+	i := sort.SearchInts(l.pinned, id)
+	if i < len(l.pinned) && l.pinned[i] == id {
+		l.propertyLock.Unlock()
+		return
+	}
+	l.pinned = append(l.pinned, 0)
+	copy(l.pinned[i+1:], l.pinned[i:])
+	l.pinned[i] = id
+	l.sectionRows.valid = false
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// UnpinItem returns a pinned item to its place among the scrolling rows.
+//
+// Since: Not a core Fyne list API
+func (l *List) UnpinItem(id ListItemID) {
+	l.propertyLock.Lock()
+	i := sort.SearchInts(l.pinned, id)
+	if i == len(l.pinned) || l.pinned[i] != id {
+		l.propertyLock.Unlock()
+		return
+	}
+	l.pinned = append(l.pinned[:i], l.pinned[i+1:]...)
+	l.sectionRows.valid = false
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// PinnedItems returns the IDs of the pinned items in order.
+//
+// Since: Not a core Fyne list API
+func (l *List) PinnedItems() []ListItemID {
+	l.propertyLock.RLock()
+	defer l.propertyLock.RUnlock()
+	return append([]ListItemID(nil), l.pinned...)
+}
+
+// returns whether the item is shown in the pinned strip.
+// The caller must hold the propertyLock.
+func (l *List) isPinned(id ListItemID) bool {
+	i := sort.SearchInts(l.pinned, id)
+	return i < len(l.pinned) && l.pinned[i] == id
+}
+
+// returns the pinned IDs, keyed by the new IDs of their items, in order
+func remapPinned(pinned []ListItemID, oldToNew []int) []ListItemID {
+	remapped := pinned[:0]
+	for _, id := range pinned {
+		if id < len(oldToNew) && oldToNew[id] >= 0 {
+			remapped = append(remapped, oldToNew[id])
+		}
+	}
+	sort.Ints(remapped)
+	return remapped
+}
+
+// binds the rows of the pinned strip to the pinned items
+func (l *listLayout) updatePinned() {
+	l.list.propertyLock.RLock()
+	ids := append([]ListItemID(nil), l.list.pinned...)
+	l.list.propertyLock.RUnlock()
+	length := l.list.length()
+	for len(ids) > 0 && ids[len(ids)-1] >= length {
+		ids = ids[:len(ids)-1] // the data has shrunk
+	}
+
+	l.renderLock.Lock()
+	for len(l.pinnedRows) < len(ids) {
+		f := l.list.CreateItem
+		if f == nil {
+			break
+		}
+		l.pinnedRows = append(l.pinnedRows, listItemAndID{item: newListItem(f(), l, nil)})
+	}
+	if len(l.pinnedRows) > len(ids) {
+		l.nilOldVisibleSliceData(l.pinnedRows, len(ids), len(l.pinnedRows))
+		l.pinnedRows = l.pinnedRows[:len(ids)]
+	}
+	objects := make([]fyne.CanvasObject, 0, len(l.pinnedRows)+1)
+	for i := range l.pinnedRows {
+		l.pinnedRows[i].id = ids[i]
+		objects = append(objects, l.pinnedRows[i].item)
+	}
+	rows := append([]listItemAndID(nil), l.pinnedRows...)
+	l.renderLock.Unlock() // user code should not be locked
+
+	if len(rows) > 0 {
+		objects = append(objects, widget.NewSeparator())
+	}
+	l.pinnedStrip.Objects = objects
+	for _, row := range rows {
+		l.setupListItem(row.item, row.id, l.list.focused && l.list.currentFocus == row.id)
+	}
+	l.pinnedStrip.Refresh()
+}
+
+// refreshes the pinned rows with IDs from start to end, inclusive
+func (l *listLayout) refreshPinned(start, end ListItemID) {
+	l.renderLock.RLock()
+	var rows []listItemAndID
+	for _, row := range l.pinnedRows {
+		if row.id >= start && row.id <= end {
+			rows = append(rows, row)
+		}
+	}
+	l.renderLock.RUnlock() // user code should not be locked
+
+	for _, row := range rows {
+		l.setupListItem(row.item, row.id, l.list.focused && l.list.currentFocus == row.id)
+	}
+}
+
+// pinnedLayout stacks the pinned rows, and a separator after them, like the rows of the list.
+type pinnedLayout struct {
+	list *List
+}
+
+func (p *pinnedLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	orient, orientPos := p.list.orient, p.list.orientPos
+	size = orient(size)
+	padding := p.list.rowPadding()
+	y := float32(0)
+	for _, o := range objects {
+		row, ok := o.(*listItem)
+		if !ok { // the separator
+			thickness := theme.SeparatorThicknessSize()
+			o.Resize(orient(fyne.NewSize(size.Width, thickness)))
+			o.Move(orientPos(fyne.NewPos(0, y-(padding+thickness)/2)))
+			continue
+		}
+		p.list.propertyLock.RLock()
+		height := p.list.itemHeight(row.id)
+		p.list.propertyLock.RUnlock()
+		row.Resize(orient(fyne.NewSize(size.Width, height)))
+		row.Move(orientPos(fyne.NewPos(0, y)))
+		y += height + padding
+	}
+}
+
+func (p *pinnedLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	height := float32(0)
+	for _, o := range objects {
+		if row, ok := o.(*listItem); ok {
+			p.list.propertyLock.RLock()
+			height += p.list.itemHeight(row.id) + p.list.rowPadding()
+			p.list.propertyLock.RUnlock()
+		}
+	}
+	return p.list.orient(fyne.NewSize(0, height))
+}
@@ -0,0 +1,113 @@
+package fyneadvancedlist
+
+import (
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// holds the rows that are not shown, to be reused as the list scrolls. Unlike sync.Pool
+// it keeps its rows across garbage collections, so that rows created up front by
+// PreallocateItems are still there when they are needed.
+type listItemPool struct {
+	lock  sync.Mutex
+	items []*listItem
+}
+
+// returns a pooled row, or nil if there is none
+func (p *listItemPool) Get() *listItem {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	n := len(p.items)
+	if n == 0 {
+		return nil
+	}
+	item := p.items[n-1]
+	p.items[n-1] = nil
+	p.items = p.items[:n-1]
+	return item
+}
+
+// adds the row to the pool, unless it already holds max rows and max is not 0
+func (p *listItemPool) Put(item *listItem, max int) {
+	p.lock.Lock()
+	if max <= 0 || len(p.items) < max {
+		p.items = append(p.items, item)
+	}
+	p.lock.Unlock()
+}
+
+// drops all pooled rows
+func (p *listItemPool) clear() {
+	p.lock.Lock()
+	p.items = nil
+	p.lock.Unlock()
+}
+
+func (p *listItemPool) len() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return len(p.items)
+}
+
+// PreallocateItems creates rows from CreateItem, a few in each frame, until n of them are
+// waiting to be shown, so that scrolling doesn't have to create rows with costly templates.
+// If the list has not been shown yet, the rows are created when it is.
+//
+// Since: Not a core Fyne list API
+func (l *List) PreallocateItems(n int) {
+	if l.scroller == nil {
+		l.preallocate = n
+		return
+	}
+	l.scroller.Content.(*fyne.Container).Layout.(*listLayout).preallocate(n)
+}
+
+// ReleasePooledItems drops the rows kept for reuse while not shown, so that their memory can be
+// freed, such as when the app is sent to the background. Rows are created again as needed.
+//
+// Since: Not a core Fyne list API
+func (l *List) ReleasePooledItems() {
+	l.preallocate = 0
+	if l.scroller != nil {
+		lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+		lo.stopPreallocating()
+		lo.itemPool.clear()
+	}
+}
+
+// how many rows PreallocateItems creates in each frame
+const preallocateBatch = 4
+
+// creates rows in the pool, a batch in each frame, until it holds n rows
+func (l *listLayout) preallocate(n int) {
+	f := l.list.CreateItem
+	if max := l.list.MaxPooledItems; max > 0 && n > max {
+		n = max
+	}
+	l.stopPreallocating()
+	if f == nil || n <= 0 {
+		return
+	}
+	var anim *fyne.Animation
+	anim = fyne.NewAnimation(time.Second, func(float32) {
+		for i := 0; i < preallocateBatch; i++ {
+			if l.itemPool.len() >= n {
+				anim.Stop()
+				return
+			}
+			l.itemPool.Put(newListItem(f(), l, nil), n)
+		}
+	})
+	anim.RepeatCount = fyne.AnimationRepeatForever
+	l.preallocateAnim = anim
+	anim.Start()
+}
+
+func (l *listLayout) stopPreallocating() {
+	if l.preallocateAnim != nil {
+		l.preallocateAnim.Stop()
+		l.preallocateAnim = nil
+	}
+}
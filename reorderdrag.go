@@ -0,0 +1,188 @@
+package fyneadvancedlist
+
+import (
+	"image/color"
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+)
+
+// alpha of the background behind the floating preview of a dragged item
+const dragGhostBackgroundAlpha = 0xc0
+
+// reorderDrag is the part of dragging to reorder items that List and GridWrapList share.
+// It works out when a press has moved far enough to start a drag, shows a semi-transparent
+// ghost of the dragged item and a line where it would be dropped, and scrolls the view
+// while the pointer is near one of its edges.
+type reorderDrag struct {
+	pending  bool          // pointer is down on an item but has not passed the drag threshold
+	pressPos fyne.Position // pointer position within the item when pressed
+
+	ghost           *fyne.Container // semi-transparent floating copy of the dragged item
+	ghostBackground *canvas.Rectangle
+	ghostItem       fyne.CanvasObject
+
+	indicator *fyne.Container // holds line and the optional caps
+	line      canvas.Rectangle
+	caps      [2]*canvas.Raster // leading, trailing
+	vertical  bool              // the line runs down, between items side by side
+
+	scrollAnim  *fyne.Animation
+	scrollSpeed float32
+	scrollStep  func(speed float32) // scrolls the view for a frame of scrollAnim
+}
+
+// creates the ghost and the indicator, which the owner adds to its objects.
+// scrollStep is called each frame while scrolling, with the speed to scroll at.
+func (d *reorderDrag) init(vertical bool, scrollStep func(speed float32)) {
+	d.vertical = vertical
+	d.scrollStep = scrollStep
+	d.line.FillColor = theme.ForegroundColor()
+	d.caps[0] = canvas.NewRasterWithPixels(d.capPixel(false))
+	d.caps[1] = canvas.NewRasterWithPixels(d.capPixel(true))
+	d.indicator = container.NewWithoutLayout(&d.line, d.caps[0], d.caps[1])
+	d.indicator.Hide()
+	d.ghostBackground = canvas.NewRectangle(color.Transparent)
+	d.ghost = container.NewStack(d.ghostBackground)
+	d.ghost.Hide()
+	styleDragGhostBackground(d.ghostBackground)
+}
+
+// returns whether the pointer dragging an item has moved at least threshold from where it was pressed
+func (d *reorderDrag) passedThreshold(e *fyne.DragEvent, threshold float32) bool {
+	if !d.pending {
+		// position of the pointer within the item when it was pressed
+		d.pending = true
+		d.pressPos = e.Position.Subtract(e.Dragged)
+	}
+	moved := e.Position.Subtract(d.pressPos)
+	if math.Hypot(float64(moved.X), float64(moved.Y)) < float64(threshold) {
+		return false
+	}
+	d.pending = false
+	return true
+}
+
+// returns the copy of an item shown by the ghost, creating it with create the first time,
+// or nil if create is nil
+func (d *reorderDrag) ghostCopy(create func() fyne.CanvasObject) fyne.CanvasObject {
+	if d.ghostItem == nil {
+		if create == nil {
+			return nil
+		}
+		d.ghostItem = create()
+		d.ghost.Add(d.ghostItem)
+	}
+	return d.ghostItem
+}
+
+// scrolls the view at speed each frame, negative to scroll back, or stops scrolling if it is 0
+func (d *reorderDrag) scrollAt(speed float32) {
+	d.scrollSpeed = speed
+	if speed == 0 {
+		d.stopScroll()
+		return
+	}
+	if d.scrollAnim == nil {
+		d.scrollAnim = fyne.NewAnimation(math.MaxInt64 /*until stopped*/, func(_ float32) {
+			d.scrollStep(d.scrollSpeed)
+		})
+		d.scrollAnim.Start()
+	}
+}
+
+func (d *reorderDrag) stopScroll() {
+	if d.scrollAnim != nil {
+		d.scrollAnim.Stop()
+		d.scrollAnim = nil
+	}
+}
+
+// hides the ghost and the indicator and stops scrolling, at the end of a drag
+func (d *reorderDrag) end() {
+	d.pending = false
+	d.stopScroll()
+	d.indicator.Hide()
+	d.ghost.Hide()
+}
+
+// sizes the indicator as a line of the given length, with its caps, returning the thickness
+// of the line and the size of the indicator across it, which is larger if it has caps
+func (d *reorderDrag) layoutIndicator(style DragIndicatorStyle, length float32) (thickness, across float32) {
+	orient := func(s fyne.Size) fyne.Size {
+		if d.vertical {
+			return fyne.NewSize(s.Height, s.Width)
+		}
+		return s
+	}
+	orientPos := func(p fyne.Position) fyne.Position {
+		if d.vertical {
+			return fyne.NewPos(p.Y, p.X)
+		}
+		return p
+	}
+	thickness, across = style.Thickness, style.Thickness
+	if style.ShowCaps && style.CapSize > across {
+		across = style.CapSize
+	}
+	d.indicator.Resize(orient(fyne.NewSize(length, across)))
+	d.line.Resize(orient(fyne.NewSize(length-2*style.Inset, thickness)))
+	d.line.Move(orientPos(fyne.NewPos(style.Inset, (across-thickness)/2)))
+	for i, cap := range d.caps {
+		if !style.ShowCaps {
+			cap.Hide()
+			continue
+		}
+		x := style.Inset
+		if i == 1 {
+			x = length - style.Inset - style.CapSize
+		}
+		cap.Resize(fyne.NewSize(style.CapSize, style.CapSize))
+		cap.Move(orientPos(fyne.NewPos(x, (across-style.CapSize)/2)))
+		cap.Show()
+	}
+	return thickness, across
+}
+
+// returns the pixels of a triangular cap at the leading or trailing end of the line
+func (d *reorderDrag) capPixel(trailing bool) func(x, y, w, h int) color.Color {
+	return func(x, y, w, h int) color.Color {
+		if d.vertical {
+			x, y, w, h = y, x, h, w // point down and up into the view instead
+		}
+		if w <= 0 || h <= 0 {
+			return color.Transparent
+		}
+		fx := (float32(x) + 0.5) / float32(w)
+		if trailing {
+			fx = 1 - fx
+		}
+		fy := (float32(y) + 0.5) / float32(h)
+		if fx <= 1-float32(math.Abs(float64(2*fy-1))) {
+			return d.line.FillColor
+		}
+		return color.Transparent
+	}
+}
+
+// updates the indicator and the ghost for the style and the current theme
+func (d *reorderDrag) refreshStyle(style DragIndicatorStyle) {
+	d.line.FillColor = style.withDefaults().Color
+	d.line.Refresh()
+	d.caps[0].Refresh()
+	d.caps[1].Refresh()
+	styleDragGhostBackground(d.ghostBackground)
+}
+
+// styles the background behind the floating preview of a dragged item for the current theme
+func styleDragGhostBackground(background *canvas.Rectangle) {
+	r, g, b, _ := theme.BackgroundColor().RGBA()
+	background.FillColor = color.NRGBA{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8), A: dragGhostBackgroundAlpha}
+	background.StrokeColor = theme.HoverColor()
+	background.StrokeWidth = theme.SeparatorThicknessSize()
+	background.CornerRadius = theme.SelectionRadiusSize()
+	background.Refresh()
+}
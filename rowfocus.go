@@ -0,0 +1,177 @@
+package fyneadvancedlist
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// The list keeps the canvas focus while the focus is in a row, and passes key events
+// on to the focused widget, so that it sees Escape, which a focused widget would not pass on.
+
+// AcceptsTab returns whether the list handles the Tab key, which it does
+// to move the focus into and between the widgets of rows if FocusRowContent is set.
+//
+// Implements: fyne.Tabbable
+func (l *List) AcceptsTab() bool {
+	return l.FocusRowContent
+}
+
+// KeyDown is called when a key is pressed while the list is focused.
+//
+// Implements: desktop.Keyable
+func (l *List) KeyDown(e *fyne.KeyEvent) {
+	if e.Name == desktop.KeyShiftLeft || e.Name == desktop.KeyShiftRight {
+		l.shiftDown = true
+	}
+	if k, ok := l.validRowFocus().(desktop.Keyable); ok {
+		k.KeyDown(e)
+	}
+}
+
+// KeyUp is called when a key is released while the list is focused.
+//
+// Implements: desktop.Keyable
+func (l *List) KeyUp(e *fyne.KeyEvent) {
+	if e.Name == desktop.KeyShiftLeft || e.Name == desktop.KeyShiftRight {
+		l.shiftDown = false
+	}
+	if k, ok := l.validRowFocus().(desktop.Keyable); ok {
+		k.KeyUp(e)
+	}
+}
+
+// handles a key typed while the focus is in the focused row, returning false if it is not
+func (l *List) typedRowKey(e *fyne.KeyEvent) bool {
+	if l.rowFocus == nil {
+		return false
+	}
+	focusables := l.rowFocusables(l.currentFocus)
+	i := indexOfFocusable(focusables, l.rowFocus)
+	if i < 0 {
+		l.exitRow() // the row has been recycled or the widget hidden
+		return false
+	}
+	switch e.Name {
+	case fyne.KeyEscape:
+		l.exitRow()
+	case fyne.KeyTab:
+		next := i + 1
+		if l.shiftDown {
+			next = i - 1
+		}
+		if next < 0 || next >= len(focusables) {
+			l.exitRow()
+		} else {
+			l.focusRowContent(focusables[next])
+		}
+	default:
+		l.rowFocus.TypedKey(e)
+	}
+	return true
+}
+
+// handles Tab while the focus is on the list itself, moving it into the focused row
+// or on to the next or previous widget of the canvas
+func (l *List) typedListTab() {
+	if !l.shiftDown && l.enterRow() {
+		return
+	}
+	c := fyne.CurrentApp().Driver().CanvasForObject(l)
+	if c == nil {
+		return
+	}
+	if l.shiftDown {
+		c.FocusPrevious()
+	} else {
+		c.FocusNext()
+	}
+}
+
+// moves the focus into the first focusable widget of the focused row,
+// returning false if it has none
+func (l *List) enterRow() bool {
+	if !l.itemEnabled(l.currentFocus) {
+		return false
+	}
+	focusables := l.rowFocusables(l.currentFocus)
+	if len(focusables) == 0 {
+		return false
+	}
+	l.focusRowContent(focusables[0])
+	return true
+}
+
+// returns the focus from a widget in the focused row to the list
+func (l *List) exitRow() {
+	f := l.rowFocus
+	if f == nil {
+		return
+	}
+	l.rowFocus = nil
+	f.FocusLost()
+	l.RefreshItem(l.currentFocus)
+}
+
+func (l *List) focusRowContent(f fyne.Focusable) {
+	if l.rowFocus != nil {
+		l.rowFocus.FocusLost()
+	}
+	l.rowFocus = f
+	f.FocusGained()
+}
+
+// returns the widget focused in the focused row, or nil if there is none
+// or the row no longer holds it
+func (l *List) validRowFocus() fyne.Focusable {
+	if l.rowFocus == nil {
+		return nil
+	}
+	if indexOfFocusable(l.rowFocusables(l.currentFocus), l.rowFocus) < 0 {
+		l.exitRow()
+		return nil
+	}
+	return l.rowFocus
+}
+
+// returns the enabled focusable widgets in the content of the row showing the item, in order.
+// Widgets are found within the containers of the content, not within other widgets.
+func (l *List) rowFocusables(id ListItemID) []fyne.Focusable {
+	if l.scroller == nil || id < 0 || l.isPlaceholder(id) {
+		return nil
+	}
+	li := l.scroller.Content.(*fyne.Container).Layout.(*listLayout).rowForItem(id)
+	if li == nil {
+		return nil
+	}
+	if li.editor != nil {
+		return appendFocusables(nil, li.editor)
+	}
+	return appendFocusables(nil, li.child)
+}
+
+func appendFocusables(focusables []fyne.Focusable, o fyne.CanvasObject) []fyne.Focusable {
+	if !o.Visible() {
+		return focusables
+	}
+	if d, ok := o.(fyne.Disableable); ok && d.Disabled() {
+		return focusables
+	}
+	if f, ok := o.(fyne.Focusable); ok {
+		return append(focusables, f)
+	}
+	if c, ok := o.(*fyne.Container); ok {
+		for _, child := range c.Objects {
+			focusables = appendFocusables(focusables, child)
+		}
+	}
+	return focusables
+}
+
+func indexOfFocusable(focusables []fyne.Focusable, f fyne.Focusable) int {
+	for i, o := range focusables {
+		if o == f {
+			return i
+		}
+	}
+	return -1
+}
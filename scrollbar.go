@@ -0,0 +1,207 @@
+package fyneadvancedlist
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ScrollBarStyle customizes the scroll bar of a list. If any field is set, the list draws
+// its own scroll bar instead of the default one, which shrinks when the pointer is not over it.
+//
+// Since: Not a core Fyne list API
+type ScrollBarStyle struct {
+	// AlwaysVisible keeps the bar at its full width whenever the list can scroll.
+	// The bar runs along the bottom of horizontal lists, and its width is then its height.
+	AlwaysVisible bool
+	// Width of the bar. Defaults to the theme scroll bar size.
+	Width float32
+	// HitWidth is the width of the area at the trailing edge of the list that can be
+	// dragged or tapped to scroll, which may be wider than the bar to make it easier to grab.
+	// Defaults to the width of the bar.
+	HitWidth float32
+	// MinLength is the shortest the bar can be in long lists. Defaults to twice its width.
+	MinLength float32
+	// Color of the bar. Defaults to the theme scroll bar color.
+	Color color.Color
+	// TrackColor fills the area behind the bar. Defaults to transparent.
+	TrackColor color.Color
+	// CornerRadius of the bar and track. Defaults to half the width of the bar.
+	CornerRadius float32
+}
+
+// returns whether any field is set. The fields are compared one by one, as comparing the
+// colors with == panics if their dynamic type is not comparable.
+func (s ScrollBarStyle) isCustom() bool {
+	return s.AlwaysVisible || s.Width != 0 || s.HitWidth != 0 || s.MinLength != 0 ||
+		s.Color != nil || s.TrackColor != nil || s.CornerRadius != 0
+}
+
+func (s ScrollBarStyle) withDefaults() ScrollBarStyle {
+	if s.Width <= 0 {
+		s.Width = theme.ScrollBarSize()
+	}
+	if s.HitWidth < s.Width {
+		s.HitWidth = s.Width
+	}
+	if s.MinLength <= 0 {
+		s.MinLength = s.Width * 2
+	}
+	if s.Color == nil {
+		s.Color = theme.ScrollBarColor()
+	}
+	if s.TrackColor == nil {
+		s.TrackColor = color.Transparent
+	}
+	if s.CornerRadius <= 0 {
+		s.CornerRadius = s.Width / 2
+	}
+	return s
+}
+
+// the smallest size of the list scroller, matching container.Scroll
+const scrollMinSize = 32
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*listScrollBar)(nil)
+var _ fyne.Draggable = (*listScrollBar)(nil)
+var _ fyne.Tappable = (*listScrollBar)(nil)
+var _ desktop.Hoverable = (*listScrollBar)(nil)
+
+// listScrollBar is the scroll bar drawn by a list with a custom ScrollBarStyle.
+type listScrollBar struct {
+	widget.BaseWidget
+	list *List
+
+	track, thumb *canvas.Rectangle
+	hovered      bool
+	dragStart    float32 // scroll offset when a drag began
+	dragDistance float32 // how far the pointer has been dragged
+	dragging     bool
+}
+
+func newListScrollBar(list *List) *listScrollBar {
+	b := &listScrollBar{list: list}
+	b.track = canvas.NewRectangle(color.Transparent)
+	b.thumb = canvas.NewRectangle(color.Transparent)
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (b *listScrollBar) CreateRenderer() fyne.WidgetRenderer {
+	b.ExtendBaseWidget(b)
+	return widget.NewSimpleRenderer(container.NewWithoutLayout(b.track, b.thumb))
+}
+
+// returns the length and position of the thumb, and the scrollable distance
+func (b *listScrollBar) thumbGeometry(style ScrollBarStyle) (length, y, scrollable float32) {
+	viewHeight := b.list.orient(b.list.scroller.Size()).Height
+	contentHeight := b.list.orient(b.list.scroller.Content.Size()).Height
+	scrollable = contentHeight - viewHeight
+	if scrollable <= 0 || viewHeight <= 0 {
+		return viewHeight, 0, 0
+	}
+	length = fyne.Max(viewHeight*viewHeight/contentHeight, style.MinLength)
+	if length > viewHeight {
+		length = viewHeight
+	}
+	y = (viewHeight - length) * b.list.scrollOffset() / scrollable
+	return length, y, scrollable
+}
+
+// Refresh updates the thumb for the current scroll offset and style.
+func (b *listScrollBar) Refresh() {
+	style := b.list.ScrollBar.withDefaults()
+	length, y, scrollable := b.thumbGeometry(style)
+	if scrollable <= 0 {
+		b.Hide()
+		return
+	}
+	b.Show()
+
+	orient, orientPos := b.list.orient, b.list.orientPos
+	size := orient(b.Size())
+	width := style.Width
+	if !style.AlwaysVisible && !b.hovered && !b.dragging {
+		width = fyne.Min(theme.ScrollBarSmallSize()*2, width)
+	}
+	x := size.Width - width
+	b.track.FillColor = style.TrackColor
+	b.track.CornerRadius = style.CornerRadius
+	b.track.Resize(orient(fyne.NewSize(width, size.Height)))
+	b.track.Move(orientPos(fyne.NewPos(x, 0)))
+	b.thumb.FillColor = style.Color
+	b.thumb.CornerRadius = fyne.Min(style.CornerRadius, width/2)
+	b.thumb.Resize(orient(fyne.NewSize(width, length)))
+	b.thumb.Move(orientPos(fyne.NewPos(x, y)))
+	b.track.Refresh()
+	b.thumb.Refresh()
+}
+
+// Dragged scrolls the list as the thumb is dragged.
+func (b *listScrollBar) Dragged(e *fyne.DragEvent) {
+	style := b.list.ScrollBar.withDefaults()
+	length, _, scrollable := b.thumbGeometry(style)
+	track := b.list.orient(b.list.scroller.Size()).Height - length
+	if scrollable <= 0 || track <= 0 {
+		return
+	}
+	if !b.dragging {
+		b.dragging = true
+		b.dragStart = b.list.scrollOffset()
+		b.dragDistance = 0
+	}
+	b.dragDistance += b.list.orientPos(fyne.NewPos(e.Dragged.DX, e.Dragged.DY)).Y
+	b.scrollTo(b.dragStart + b.dragDistance*scrollable/track)
+}
+
+// DragEnd is called when the thumb is released.
+func (b *listScrollBar) DragEnd() {
+	b.dragging = false
+	b.Refresh()
+}
+
+// Tapped scrolls the list by a page towards the tapped position.
+func (b *listScrollBar) Tapped(e *fyne.PointEvent) {
+	_, y, _ := b.thumbGeometry(b.list.ScrollBar.withDefaults())
+	page := b.list.orient(b.list.scroller.Size()).Height
+	if b.list.orientPos(e.Position).Y < y {
+		page = -page
+	}
+	b.scrollTo(b.list.scrollOffset() + page)
+}
+
+// MouseIn widens the bar while the pointer is over it.
+func (b *listScrollBar) MouseIn(*desktop.MouseEvent) {
+	b.hovered = true
+	b.Refresh()
+}
+
+// MouseMoved is called when the pointer moves over the bar.
+func (b *listScrollBar) MouseMoved(*desktop.MouseEvent) {
+}
+
+// MouseOut is called when the pointer leaves the bar.
+func (b *listScrollBar) MouseOut() {
+	b.hovered = false
+	b.Refresh()
+}
+
+func (b *listScrollBar) scrollTo(offset float32) {
+	scroller := b.list.scroller
+	if max := b.list.orient(scroller.Content.Size()).Height - b.list.orient(scroller.Size()).Height; offset > max {
+		offset = max
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	b.list.setScrollOffset(offset)
+	scroller.Refresh()
+	b.list.offsetUpdated(scroller.Offset)
+}
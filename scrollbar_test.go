@@ -0,0 +1,35 @@
+package fyneadvancedlist
+
+import (
+	"image/color"
+	"testing"
+)
+
+// a color whose dynamic type can't be compared with ==
+type paletteColor []color.NRGBA
+
+func (c paletteColor) RGBA() (r, g, b, a uint32) {
+	return c[0].RGBA()
+}
+
+func TestScrollBarStyle_IsCustom(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		style ScrollBarStyle
+		want  bool
+	}{
+		{name: "zero", want: false},
+		{name: "always visible", style: ScrollBarStyle{AlwaysVisible: true}, want: true},
+		{name: "width", style: ScrollBarStyle{Width: 4}, want: true},
+		{name: "hit width", style: ScrollBarStyle{HitWidth: 12}, want: true},
+		{name: "min length", style: ScrollBarStyle{MinLength: 20}, want: true},
+		{name: "corner radius", style: ScrollBarStyle{CornerRadius: 2}, want: true},
+		{name: "color", style: ScrollBarStyle{Color: color.Black}, want: true},
+		{name: "uncomparable color", style: ScrollBarStyle{Color: paletteColor{{A: 0xff}}}, want: true},
+		{name: "uncomparable track color", style: ScrollBarStyle{TrackColor: paletteColor{{A: 0xff}}}, want: true},
+	} {
+		if got := tt.style.isCustom(); got != tt.want {
+			t.Errorf("%s: isCustom() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
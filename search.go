@@ -0,0 +1,98 @@
+package fyneadvancedlist
+
+import (
+	"image/color"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+)
+
+// Search returns the items shown by the list for which matcher returns true, in the order
+// of their rows, and keeps them as the matches that NextMatch and PrevMatch move between.
+// An empty query clears the matches.
+//
+// Since: Not a core Fyne list API
+func (l *List) Search(query string, matcher func(id ListItemID, query string) bool) []ListItemID {
+	l.matches, l.matchIndex = nil, -1
+	if query == "" || matcher == nil {
+		return nil
+	}
+	length := l.length()
+	l.propertyLock.Lock()
+	rows := make([]ListItemID, l.rowCount(length))
+	for row := range rows {
+		rows[row] = l.rowItem(row)
+	}
+	l.propertyLock.Unlock()
+	for _, id := range rows {
+		if !l.isPlaceholder(id) && matcher(id, query) {
+			l.matches = append(l.matches, id)
+		}
+	}
+	return l.matches
+}
+
+// NextMatch scrolls to the match after the last one navigated to, wrapping around
+// to the first, and briefly highlights its row. It returns false if there are no matches.
+//
+// Since: Not a core Fyne list API
+func (l *List) NextMatch() (ListItemID, bool) {
+	return l.gotoMatch(1)
+}
+
+// PrevMatch scrolls to the match before the last one navigated to, wrapping around
+// to the last, and briefly highlights its row. It returns false if there are no matches.
+//
+// Since: Not a core Fyne list API
+func (l *List) PrevMatch() (ListItemID, bool) {
+	return l.gotoMatch(-1)
+}
+
+func (l *List) gotoMatch(dir int) (ListItemID, bool) {
+	n := len(l.matches)
+	if n == 0 {
+		return -1, false
+	}
+	if l.matchIndex < 0 && dir < 0 {
+		l.matchIndex = 0
+	}
+	l.matchIndex = ((l.matchIndex+dir)%n + n) % n
+	id := l.matches[l.matchIndex]
+	l.ScrollTo(id)
+	if l.scroller != nil {
+		l.scroller.Content.(*fyne.Container).Layout.(*listLayout).flashItem(id)
+	}
+	return id, true
+}
+
+// how long the row of a match navigated to stays highlighted
+const matchFlashDuration = 800 * time.Millisecond
+
+// briefly highlights the row showing the item
+func (l *listLayout) flashItem(id ListItemID) {
+	li := l.rowForItem(id)
+	if li == nil || li.flash == nil {
+		return
+	}
+	if li.flashAnim != nil {
+		li.flashAnim.Stop()
+	}
+	flash := color.NRGBAModel.Convert(theme.PrimaryColor()).(color.NRGBA)
+	li.flash.CornerRadius = theme.SelectionRadiusSize()
+	li.flash.Show()
+	li.flashAnim = fyne.NewAnimation(matchFlashDuration, func(f float32) {
+		if f == 1 || li.id != id { // done, or the row has been recycled
+			li.flash.Hide()
+			li.flash.Refresh()
+			return
+		}
+		c := flash
+		c.A = uint8(float32(flash.A) * (1 - f) / 2)
+		li.flash.FillColor = c
+		canvas.Refresh(li.flash)
+	})
+	li.flashAnim.Curve = fyne.AnimationEaseIn
+	li.flashAnim.Start()
+}
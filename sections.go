@@ -0,0 +1,408 @@
+package fyneadvancedlist
+
+import (
+	"math"
+	"sort"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// sectionRows maps the rows of a list to the items they show,
+// which differ when sections are collapsed or items are pinned.
+type sectionRows struct {
+	valid  bool
+	length int          // the number of items the rows were built for
+	rows   []ListItemID // the shown items in order, or nil if every item is shown
+	rowOf  []int        // the row of each item in a sorted list, or -1 if it is not shown
+}
+
+// SetSectionCollapsed collapses or expands the section that begins with the item id,
+// so that only its header or all of its rows are shown.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetSectionCollapsed(id ListItemID, collapsed bool) {
+	l.propertyLock.Lock()
+	if l.collapsedSections[id] == collapsed {
+		l.propertyLock.Unlock()
+		return
+	}
+	if collapsed {
+		if l.collapsedSections == nil {
+			l.collapsedSections = make(map[ListItemID]bool)
+		}
+		l.collapsedSections[id] = true
+	} else {
+		delete(l.collapsedSections, id)
+	}
+	l.sectionRows.valid = false
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+
+	l.refreshLayout()
+	l.RefreshRange(id, id)
+}
+
+// IsSectionCollapsed returns whether the section that begins with the item id is collapsed.
+//
+// Since: Not a core Fyne list API
+func (l *List) IsSectionCollapsed(id ListItemID) bool {
+	l.propertyLock.RLock()
+	defer l.propertyLock.RUnlock()
+	return l.collapsedSections[id]
+}
+
+// toggles the section that begins with id after its header was tapped
+func (l *List) sectionHeaderTapped(id ListItemID) {
+	if !l.CollapsibleSections {
+		return
+	}
+	collapsed := !l.IsSectionCollapsed(id)
+	l.SetSectionCollapsed(id, collapsed)
+	if f := l.OnSectionToggled; f != nil {
+		f(id, collapsed)
+	}
+}
+
+// SetGroupBy divides the list into sections of consecutive items with the same group key.
+// Unless CreateSectionHeader and UpdateSectionHeader are set, each section has a header
+// showing its key. IsSectionStart takes precedence if it is set. Pass nil to remove the sections.
+// As with IsSectionStart, the key function must not call methods of the list.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetGroupBy(key func(id ListItemID) string) {
+	l.propertyLock.Lock()
+	l.groupBy = key
+	l.headerMinSizes = [2]float32{} // the default header template may be used instead
+	l.sectionRows.valid = false
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// SetFilter shows only the items for which filter returns true, without changing their IDs,
+// so the callbacks and the selection still refer to the items of the data. Call it again
+// after the data changes in a way that affects the filter. Pass nil to show every item.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetFilter(filter func(id ListItemID) bool) {
+	l.propertyLock.Lock()
+	l.filter = filter
+	l.sectionRows.valid = false
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// SetSortOrder shows the rows in the order given by less, without changing the order of the data
+// or the IDs of the items, so the callbacks, including OnDragEnd, and the selection still refer to
+// the items of the data. Call it again after the data changes. Pass nil to show the items in order.
+// Sections are not kept together, so sorted lists should not be divided into sections.
+//
+// Since: Not a core Fyne list API
+func (l *List) SetSortOrder(less func(a, b ListItemID) bool) {
+	l.propertyLock.Lock()
+	l.sortLess = less
+	l.sectionRows.valid = false
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+	l.Refresh()
+}
+
+// returns whether the list is divided into sections
+func (l *List) hasSections() bool {
+	return l.IsSectionStart != nil || l.groupBy != nil
+}
+
+// returns whether a section header is shown above the item.
+// It is called with the propertyLock held while laying out rows, so IsSectionStart and the
+// group key mustn't call into the list.
+func (l *List) isSectionStart(id ListItemID) bool {
+	if l.isPlaceholder(id) {
+		return false
+	}
+	if f := l.IsSectionStart; f != nil {
+		return f(id)
+	}
+	if key := l.groupBy; key != nil {
+		return id == 0 || key(id) != key(id-1)
+	}
+	return false
+}
+
+// returns a new section header, or nil if the list has no header template
+func (l *List) createSectionHeader() fyne.CanvasObject {
+	if f := l.CreateSectionHeader; f != nil {
+		return f()
+	}
+	if l.groupBy != nil {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	return nil
+}
+
+// measures the section header template, unless it has been measured with the current theme
+// sizes, as with the item template in updateItemMin
+func (l *List) updateHeaderMin() {
+	sizes := [2]float32{theme.TextSize(), theme.Padding()}
+	if sizes == l.headerMinSizes {
+		return
+	}
+	header := l.createSectionHeader()
+	if header == nil {
+		return
+	}
+	min := l.orient(header.MinSize())
+	l.propertyLock.Lock()
+	l.headerMin, l.headerMinSizes = min, sizes
+	l.heightIndex.valid = false
+	l.propertyLock.Unlock()
+}
+
+// shows the section beginning with the item id in header
+func (l *List) updateSectionHeader(id ListItemID, header fyne.CanvasObject) {
+	if f := l.UpdateSectionHeader; f != nil {
+		f(id, header)
+		return
+	}
+	if label, ok := header.(*widget.Label); ok && l.groupBy != nil {
+		label.SetText(l.groupBy(id))
+	}
+}
+
+// returns the items shown by the rows of a list of length items, or nil if every item is shown.
+// The caller must hold the propertyLock for writing.
+func (l *List) shownRows(length int) []ListItemID {
+	s := &l.sectionRows
+	if s.valid && s.length == length {
+		return s.rows
+	}
+	s.valid, s.length = true, length
+	s.rows = s.rows[:0]
+	l.heightIndex.valid = false
+	s.rowOf = nil
+	if (len(l.collapsedSections) == 0 || !l.hasSections()) && len(l.pinned) == 0 && l.filter == nil && l.sortLess == nil {
+		s.rows = nil
+		return nil
+	}
+	if s.rows == nil {
+		// not nil even if no item is shown, as nil means that every item is
+		s.rows = make([]ListItemID, 0, length)
+	}
+
+	collapsed := false
+	sections := l.hasSections()
+	for id := 0; id < length; id++ {
+		if sections && l.isSectionStart(id) {
+			collapsed = l.collapsedSections[id]
+		} else if collapsed {
+			continue
+		}
+		if l.isPinned(id) || (l.filter != nil && !l.filter(id)) {
+			continue
+		}
+		s.rows = append(s.rows, id)
+	}
+	if less := l.sortLess; less != nil {
+		sort.SliceStable(s.rows, func(i, j int) bool { return less(s.rows[i], s.rows[j]) })
+		s.rowOf = make([]int, length)
+		for id := range s.rowOf {
+			s.rowOf[id] = -1
+		}
+		for row, id := range s.rows {
+			s.rowOf[id] = row
+		}
+	}
+	return s.rows
+}
+
+// returns the number of rows shown for a list of length items.
+// The caller must hold the propertyLock for writing.
+func (l *List) rowCount(length int) int {
+	if rows := l.shownRows(length); rows != nil {
+		return len(rows)
+	}
+	return length
+}
+
+// returns the item shown by a row, using the rows last returned by shownRows.
+// The caller must hold the propertyLock.
+func (l *List) rowItem(row int) ListItemID {
+	if rows := l.sectionRows.rows; rows != nil && row < len(rows) {
+		return rows[row]
+	}
+	return row
+}
+
+// returns the row showing an item, using the rows last returned by shownRows.
+// If the item is in a collapsed section, the row of its section header is returned with false.
+// The caller must hold the propertyLock.
+func (l *List) itemRow(id ListItemID) (int, bool) {
+	rows := l.sectionRows.rows
+	if rows == nil {
+		return id, true
+	}
+	if rowOf := l.sectionRows.rowOf; rowOf != nil {
+		if id < 0 || id >= len(rowOf) || rowOf[id] < 0 {
+			return 0, false
+		}
+		return rowOf[id], true
+	}
+	row := sort.SearchInts(rows, id)
+	if row < len(rows) && rows[row] == id {
+		return row, true
+	}
+	if row > 0 {
+		row--
+	}
+	return row, false
+}
+
+// returns the item shown in the row after (dir > 0) or before (dir < 0) the item id,
+// or -1 if there is none.
+func (l *List) adjacentItem(id ListItemID, dir int) ListItemID {
+	length := l.length()
+	l.propertyLock.Lock()
+	defer l.propertyLock.Unlock()
+	rows := l.rowCount(length)
+	row, shown := l.itemRow(id)
+	if shown || dir > 0 {
+		row += dir
+	}
+	if row < 0 || row >= rows {
+		return -1
+	}
+	return l.rowItem(row)
+}
+
+// returns the height of the section header shown above the item, including the padding below it,
+// or the height of the header alone if the section is collapsed.
+// The caller must hold the propertyLock.
+func (l *List) sectionHeaderHeight(id ListItemID) (height float32, collapsed bool) {
+	if !l.isSectionStart(id) {
+		return 0, false
+	}
+	if l.collapsedSections[id] {
+		return l.headerMin.Height, true
+	}
+	return l.headerMin.Height + theme.Padding(), false
+}
+
+// shows or hides the section header of a row and the content of a collapsed section header
+func (l *listLayout) setupSectionHeader(li *listItem, id ListItemID) {
+	start := l.list.isSectionStart(id)
+	collapsed := start && l.list.IsSectionCollapsed(id)
+	if start && len(li.headerBox.Objects) == 0 {
+		if header := l.list.createSectionHeader(); header != nil {
+			li.headerBox.Add(header)
+		}
+	}
+	if start && len(li.headerBox.Objects) > 0 {
+		l.list.updateSectionHeader(id, li.headerBox.Objects[0])
+	}
+
+	if start == li.headerBox.Visible() && collapsed == !li.child.Visible() {
+		return
+	}
+	if start {
+		li.headerBox.Show()
+	} else {
+		li.headerBox.Hide()
+	}
+	if collapsed {
+		li.child.Hide()
+	} else {
+		li.child.Show()
+	}
+	if li.content != nil {
+		li.content.Layout.Layout(li.content.Objects, li.content.Size())
+	}
+}
+
+// listItemLayout places the section header, if shown, above the content of a row,
+// or before it in a horizontal list. Swiping the row moves the content across the row
+// and places the swipe actions beside it.
+type listItemLayout struct {
+	li *listItem
+}
+
+func (r *listItemLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	list := r.li.listLayout.list
+	size = list.orient(size)
+	top := float32(0)
+	if header := r.li.headerBox; header.Visible() {
+		height := list.headerMin.Height
+		header.Resize(list.orient(fyne.NewSize(size.Width, height)))
+		header.Move(fyne.NewPos(0, 0))
+		top = height + theme.Padding()
+	}
+	height := fyne.Max(size.Height-top, 0)
+	contentSize := list.orient(fyne.NewSize(size.Width, height))
+	shift := r.li.listLayout.swipeOffset(r.li.id)
+	mark, grip := accessoryWidth(list, r.li.editMark), accessoryWidth(list, r.li.grip)
+	lead := mark + accessoryWidth(list, r.li.leading)
+	trail := accessoryWidth(list, r.li.trailing) + grip
+	for _, o := range objects {
+		switch o {
+		case r.li.headerBox:
+			continue
+		case r.li.editMark:
+			o.Resize(list.orient(fyne.NewSize(mark, height)))
+			o.Move(list.orientPos(fyne.NewPos(shift, top)))
+			continue
+		case r.li.leading:
+			o.Resize(list.orient(fyne.NewSize(lead-mark, height)))
+			o.Move(list.orientPos(fyne.NewPos(shift+mark, top)))
+			continue
+		case r.li.trailing:
+			o.Resize(list.orient(fyne.NewSize(trail-grip, height)))
+			o.Move(list.orientPos(fyne.NewPos(shift+size.Width-trail, top)))
+			continue
+		case r.li.grip:
+			o.Resize(list.orient(fyne.NewSize(grip, height)))
+			o.Move(list.orientPos(fyne.NewPos(shift+size.Width-grip, top)))
+			continue
+		case r.li.child, r.li.editor:
+			o.Resize(list.orient(fyne.NewSize(fyne.Max(size.Width-lead-trail, 0), height)))
+			o.Move(list.orientPos(fyne.NewPos(shift+lead, top)))
+			continue
+		}
+		if o == r.li.swipeBox {
+			x := float32(0)
+			if shift < 0 {
+				x = size.Width + shift
+			}
+			o.Resize(list.orient(fyne.NewSize(float32(math.Abs(float64(shift))), height)))
+			o.Move(list.orientPos(fyne.NewPos(x, top)))
+			continue
+		}
+		o.Resize(contentSize)
+		o.Move(list.orientPos(fyne.NewPos(shift, top)))
+	}
+}
+
+func (r *listItemLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	return r.li.contentMinSize()
+}
+
+// returns the min size of the row content together with its accessories and edit marks
+func (li *listItem) contentMinSize() fyne.Size {
+	list := li.listLayout.list
+	min := list.orient(li.child.MinSize())
+	for _, a := range [...]fyne.CanvasObject{li.editMark, li.leading, li.trailing, li.grip} {
+		if a != nil && a.Visible() {
+			size := list.orient(a.MinSize())
+			min = fyne.NewSize(min.Width+size.Width, fyne.Max(min.Height, size.Height))
+		}
+	}
+	return list.orient(min)
+}
+
+// returns the width of an accessory beside the row content, which is 0 if it is not shown
+func accessoryWidth(list *List, accessory fyne.CanvasObject) float32 {
+	if accessory == nil || !accessory.Visible() {
+		return 0
+	}
+	return list.orient(accessory.MinSize()).Width
+}
@@ -0,0 +1,92 @@
+package fyneadvancedlist
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+)
+
+func newTestList(length int) *List {
+	return NewList(
+		func() int { return length },
+		func() fyne.CanvasObject { return widget.NewLabel("template") },
+		func(ListItemID, fyne.CanvasObject) {},
+	)
+}
+
+func TestList_SetFilterMatchingNothing(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	list := newTestList(10)
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+
+	list.SetFilter(func(ListItemID) bool { return false })
+	list.propertyLock.Lock()
+	rows := list.rowCount(10)
+	list.propertyLock.Unlock()
+	if rows != 0 {
+		t.Errorf("rowCount = %d, want 0", rows)
+	}
+	lo := list.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	if n := len(lo.visible); n != 0 {
+		t.Errorf("%d rows shown, want none", n)
+	}
+
+	list.SetFilter(func(id ListItemID) bool { return id%2 == 0 })
+	list.propertyLock.Lock()
+	rows = list.rowCount(10)
+	list.propertyLock.Unlock()
+	if rows != 5 {
+		t.Errorf("rowCount = %d, want 5", rows)
+	}
+}
+
+func TestList_OnlyPinnedItems(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	list := newTestList(1)
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+
+	list.PinItem(0)
+	list.propertyLock.Lock()
+	rows := list.rowCount(1)
+	list.propertyLock.Unlock()
+	if rows != 0 {
+		t.Errorf("rowCount = %d, want 0 as the only item is pinned", rows)
+	}
+	lo := list.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	if n := len(lo.visible); n != 0 {
+		t.Errorf("%d rows shown in the scroller, want none", n)
+	}
+}
+
+func TestList_SectionHeaderTemplateMeasuredOnce(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	list := newTestList(10)
+	created := 0
+	list.IsSectionStart = func(ListItemID) bool { return false }
+	list.CreateSectionHeader = func() fyne.CanvasObject {
+		created++
+		return widget.NewLabel("header")
+	}
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.Resize(fyne.NewSize(200, 400))
+
+	list.Refresh()
+	list.Refresh()
+	if created != 1 {
+		t.Errorf("section header template created %d times, want once", created)
+	}
+	list.InvalidateItemMinSize()
+	if created != 2 {
+		t.Errorf("section header template created %d times after InvalidateItemMinSize, want twice", created)
+	}
+}
@@ -0,0 +1,92 @@
+package fyneadvancedlist
+
+import "strconv"
+
+// ListState is the UI state of a list, as returned by SaveState, which can be stored,
+// such as encoded as JSON, and restored with RestoreState. Items are identified by the keys
+// returned by List.ItemKey, or by their IDs if it is not set.
+//
+// Since: Not a core Fyne list API
+type ListState struct {
+	// ScrollAnchor is the item at the top of the visible area, and ScrollOffset how far
+	// the list is scrolled past the top of its row, see List.ScrollAnchor.
+	ScrollAnchor string   `json:"scrollAnchor,omitempty"`
+	ScrollOffset float32  `json:"scrollOffset,omitempty"`
+	Selected     []string `json:"selected,omitempty"`
+	Focused      string   `json:"focused,omitempty"`
+	// CollapsedSections holds the first items of the collapsed sections.
+	CollapsedSections []string `json:"collapsedSections,omitempty"`
+}
+
+// SaveState returns the scroll position, selection, focused item and collapsed sections of the list.
+//
+// Since: Not a core Fyne list API
+func (l *List) SaveState() ListState {
+	var state ListState
+	if id, offset := l.ScrollAnchor(); id >= 0 {
+		state.ScrollAnchor, state.ScrollOffset = l.itemKey(id), offset
+	}
+	l.propertyLock.RLock()
+	selected := append([]ListItemID(nil), l.selected...)
+	focused := l.currentFocus
+	collapsed := make([]ListItemID, 0, len(l.collapsedSections))
+	for id := range l.collapsedSections {
+		collapsed = append(collapsed, id)
+	}
+	l.propertyLock.RUnlock()
+	for _, id := range selected {
+		state.Selected = append(state.Selected, l.itemKey(id))
+	}
+	if focused < l.length() {
+		state.Focused = l.itemKey(focused)
+	}
+	for _, id := range collapsed {
+		state.CollapsedSections = append(state.CollapsedSections, l.itemKey(id))
+	}
+	return state
+}
+
+// RestoreState restores the state returned by SaveState, ignoring items that are no longer in the list.
+//
+// Since: Not a core Fyne list API
+func (l *List) RestoreState(state ListState) {
+	ids := l.itemIDsByKey()
+	for _, key := range state.CollapsedSections {
+		if id, ok := ids[key]; ok {
+			l.SetSectionCollapsed(id, true)
+		}
+	}
+	for _, key := range state.Selected {
+		if id, ok := ids[key]; ok {
+			l.Select(id)
+		}
+	}
+	if id, ok := ids[state.Focused]; ok {
+		l.RefreshItem(l.currentFocus)
+		l.currentFocus = id
+		l.RefreshItem(id)
+	}
+	if id, ok := ids[state.ScrollAnchor]; ok {
+		l.RestoreScrollAnchor(id, state.ScrollOffset)
+	}
+}
+
+// returns the key of the item, see ItemKey
+func (l *List) itemKey(id ListItemID) string {
+	if f := l.ItemKey; f != nil {
+		return f(id)
+	}
+	return strconv.Itoa(id)
+}
+
+// returns the IDs of all items, keyed by itemKey
+func (l *List) itemIDsByKey() map[string]ListItemID {
+	length := l.length()
+	ids := make(map[string]ListItemID, length)
+	for id := 0; id < length; id++ {
+		if !l.isPlaceholder(id) {
+			ids[l.itemKey(id)] = id
+		}
+	}
+	return ids
+}
@@ -0,0 +1,204 @@
+package fyneadvancedlist
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SwipeAction is a button revealed behind a row when it is swiped across the list,
+// such as to delete or archive the item.
+//
+// Since: Not a core Fyne list API
+type SwipeAction struct {
+	Label      string
+	Icon       fyne.Resource
+	Importance widget.Importance // such as widget.DangerImportance for a delete action
+
+	// OnTapped is called with the ID of the swiped item when the action is tapped.
+	// The row is closed first, so the item may be removed from the list.
+	OnTapped func(id ListItemID) `json:"-"`
+}
+
+// CloseSwipes hides the actions of any row that has been swiped open.
+//
+// Since: Not a core Fyne list API
+func (l *List) CloseSwipes() {
+	if l.scroller == nil {
+		return
+	}
+	l.scroller.Content.(*fyne.Container).Layout.(*listLayout).animateSwipe(0)
+}
+
+// returns the swipe actions of the item on the leading or trailing side
+func (l *List) swipeActions(id ListItemID, leading bool) []SwipeAction {
+	f := l.TrailingSwipeActions
+	if leading {
+		f = l.LeadingSwipeActions
+	}
+	if f == nil || l.isPlaceholder(id) {
+		return nil
+	}
+	return f(id)
+}
+
+// returns the offset of the item's row content across the list, which is non-zero
+// while the row is swiped open
+func (l *listLayout) swipeOffset(id ListItemID) float32 {
+	if id != l.swipedRow {
+		return 0
+	}
+	return l.swipeShift
+}
+
+// starts swiping the row if the drag begins across the list and the item has swipe actions
+func (li *listItem) startSwipe(e *fyne.DragEvent) bool {
+	l := li.listLayout
+	if li.scrolling || li.dragArmed || li.disabled || l.draggingRow >= 0 || l.drag.pending || l.list.editMode ||
+		(l.list.LeadingSwipeActions == nil && l.list.TrailingSwipeActions == nil) {
+		return false
+	}
+	d := l.list.orientPos(fyne.NewPos(e.Dragged.DX, e.Dragged.DY))
+	if d.X*d.X <= d.Y*d.Y {
+		return false
+	}
+	if len(l.list.swipeActions(li.id, d.X > 0)) == 0 && l.swipeOffset(li.id) == 0 {
+		return false
+	}
+	li.cancelLongPress()
+	l.stopSwipeAnim()
+	if l.swipedRow != li.id {
+		l.setSwipe(-1, 0)
+		l.swipedRow = li.id
+	}
+	li.swiping = true
+	return true
+}
+
+func (li *listItem) swipeDragged(e *fyne.DragEvent) {
+	l := li.listLayout
+	shift := l.swipeShift + l.list.orientPos(fyne.NewPos(e.Dragged.DX, e.Dragged.DY)).X
+	if shift > 0 && len(l.list.swipeActions(li.id, true)) == 0 ||
+		shift < 0 && len(l.list.swipeActions(li.id, false)) == 0 {
+		shift = 0
+	}
+	width := l.list.orient(li.Size()).Width
+	shift = fyne.Min(fyne.Max(shift, -width), width)
+	l.setSwipe(li.id, shift)
+}
+
+// snaps the swiped row open to show all of its actions, or closed
+func (li *listItem) swipeEnd() {
+	li.swiping = false
+	l := li.listLayout
+	reveal := l.list.orient(li.swipeBox.MinSize()).Width
+	switch shift := l.swipeOffset(li.id); {
+	case shift > reveal/2:
+		l.animateSwipe(reveal)
+	case shift < -reveal/2:
+		l.animateSwipe(-reveal)
+	default:
+		l.animateSwipe(0)
+	}
+}
+
+// sets the swiped row and the offset of its content, closing any other row at once
+func (l *listLayout) setSwipe(id ListItemID, shift float32) {
+	previous := l.swipedRow
+	l.swipedRow, l.swipeShift = id, shift
+	if shift == 0 {
+		l.swipedRow = -1
+	}
+	if previous >= 0 && previous != id {
+		if li := l.rowForItem(previous); li != nil {
+			li.syncSwipe()
+		}
+	}
+	if id >= 0 {
+		if li := l.rowForItem(id); li != nil {
+			li.syncSwipe()
+		}
+	}
+}
+
+// animates the content of the swiped row to the offset, closing it if 0
+func (l *listLayout) animateSwipe(to float32) {
+	l.stopSwipeAnim()
+	id, from := l.swipedRow, l.swipeShift
+	if id < 0 || from == to {
+		return
+	}
+	l.swipeAnim = fyne.NewAnimation(canvas.DurationShort, func(f float32) {
+		l.setSwipe(id, from+(to-from)*f)
+	})
+	l.swipeAnim.Curve = fyne.AnimationEaseOut
+	l.swipeAnim.Start()
+}
+
+func (l *listLayout) stopSwipeAnim() {
+	if l.swipeAnim != nil {
+		l.swipeAnim.Stop()
+		l.swipeAnim = nil
+	}
+}
+
+// returns the row showing the item, if it is visible or pinned
+func (l *listLayout) rowForItem(id ListItemID) *listItem {
+	l.renderLock.RLock()
+	defer l.renderLock.RUnlock()
+	if li, ok := l.searchVisible(l.visible, id); ok {
+		return li
+	}
+	if li, ok := l.searchVisible(l.pinnedRows, id); ok {
+		return li
+	}
+	return nil
+}
+
+// updates the swipe actions and the position of the row content to the swipe offset of its item
+func (li *listItem) syncSwipe() {
+	if li.content == nil {
+		return
+	}
+	shift := li.listLayout.swipeOffset(li.id)
+	if shift == 0 && !li.swipeBox.Visible() {
+		return
+	}
+	if shift == 0 {
+		li.swipeBox.Hide()
+		li.swipeBox.Objects = nil
+	} else if leading := shift > 0; li.swipeBox.Objects == nil || leading != li.swipeLeading || li.swipeID != li.id {
+		li.swipeLeading, li.swipeID = leading, li.id
+		li.swipeBox.Objects = li.swipeButtons(leading)
+		li.swipeBox.Show()
+	}
+	li.content.Refresh()
+}
+
+func (li *listItem) swipeButtons(leading bool) []fyne.CanvasObject {
+	list := li.listLayout.list
+	id := li.id
+	actions := list.swipeActions(id, leading)
+	buttons := make([]fyne.CanvasObject, len(actions))
+	for i, a := range actions {
+		onTapped := a.OnTapped
+		b := widget.NewButtonWithIcon(a.Label, a.Icon, func() {
+			li.listLayout.stopSwipeAnim()
+			li.listLayout.setSwipe(-1, 0)
+			if onTapped != nil {
+				onTapped(id)
+			}
+		})
+		b.Importance = a.Importance
+		buttons[i] = b
+	}
+	if len(buttons) == 0 {
+		li.swipeBox.Layout = nil
+	} else if list.horizontal {
+		li.swipeBox.Layout = layout.NewGridLayoutWithRows(len(buttons))
+	} else {
+		li.swipeBox.Layout = layout.NewGridLayoutWithColumns(len(buttons))
+	}
+	return buttons
+}
@@ -0,0 +1,404 @@
+package fyneadvancedlist
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// TableColumn describes a column of a TableList.
+//
+// Since: Not a core Fyne list API
+type TableColumn struct {
+	// Title is shown in the header row above the column.
+	Title string
+	// Width of the column. If it is not set, the MinSize width of the cell template is used.
+	Width float32
+}
+
+// the narrowest a column can be resized to by dragging its divider
+const minColumnWidth = 24
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*TableList)(nil)
+
+// TableList is a widget that shows the rows of a List divided into columns with shared widths,
+// below a header row of column titles. Columns can be resized by dragging the dividers between
+// their titles. Unlike widget.Table, the rows are virtualized like any list and can be reordered
+// by dragging them.
+//
+// Since: Not a core Fyne list API
+type TableList struct {
+	widget.BaseWidget
+
+	// List shows the rows of the table. Set its fields, such as OnSelected or EnableDragging,
+	// to use its selection and reordering.
+	List *List
+
+	// Columns of the table. Call Refresh after changing them.
+	Columns    []TableColumn
+	CreateCell func(column int) fyne.CanvasObject                      `json:"-"`
+	UpdateCell func(id ListItemID, column int, cell fyne.CanvasObject) `json:"-"`
+
+	// OnColumnResized is called after a column has been resized by dragging its divider.
+	OnColumnResized func(column int, width float32) `json:"-"`
+
+	header    *tableHeader
+	cellWidth []float32 // MinSize widths of the cell templates, for columns without a Width
+}
+
+// NewTableList creates and returns a table widget with the given columns, for displaying
+// length rows with scrolling and caching for performance.
+//
+// Since: Not a core Fyne list API
+func NewTableList(length func() int, columns []TableColumn, createCell func(column int) fyne.CanvasObject, updateCell func(id ListItemID, column int, cell fyne.CanvasObject)) *TableList {
+	t := newTableList(columns, createCell, updateCell)
+	t.List = NewList(length, t.createRow, t.updateRow)
+	return t
+}
+
+// NewReorderableTableList creates a table with dragging enabled that displays the items of adapter,
+// like NewReorderableList.
+//
+// Since: Not a core Fyne list API
+func NewReorderableTableList(adapter ReorderableAdapter, columns []TableColumn, createCell func(column int) fyne.CanvasObject, updateCell func(id ListItemID, column int, cell fyne.CanvasObject)) *TableList {
+	t := newTableList(columns, createCell, updateCell)
+	t.List = NewReorderableList(adapter, t.createRow, t.updateRow)
+	return t
+}
+
+func newTableList(columns []TableColumn, createCell func(column int) fyne.CanvasObject, updateCell func(id ListItemID, column int, cell fyne.CanvasObject)) *TableList {
+	t := &TableList{Columns: columns, CreateCell: createCell, UpdateCell: updateCell}
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (t *TableList) CreateRenderer() fyne.WidgetRenderer {
+	t.ExtendBaseWidget(t)
+	t.updateCellWidths()
+	t.header = newTableHeader(t)
+	return &tableListRenderer{table: t, objects: []fyne.CanvasObject{t.header, t.List}}
+}
+
+// returns the width of the column
+func (t *TableList) columnWidth(column int) float32 {
+	if w := t.Columns[column].Width; w > 0 {
+		return w
+	}
+	if column < len(t.cellWidth) {
+		return t.cellWidth[column]
+	}
+	return minColumnWidth
+}
+
+// returns the width of all columns, including the padding between them
+func (t *TableList) rowWidth() float32 {
+	width := float32(0)
+	for i := range t.Columns {
+		if i > 0 {
+			width += theme.Padding()
+		}
+		width += t.columnWidth(i)
+	}
+	return width
+}
+
+func (t *TableList) updateCellWidths() {
+	t.cellWidth = t.cellWidth[:0]
+	f := t.CreateCell
+	for i := range t.Columns {
+		width := float32(minColumnWidth)
+		if f != nil && t.Columns[i].Width <= 0 {
+			width = fyne.Max(f(i).MinSize().Width, width)
+		}
+		t.cellWidth = append(t.cellWidth, width)
+	}
+}
+
+// returns a row with a cell for each column
+func (t *TableList) createRow() fyne.CanvasObject {
+	row := container.New(&tableRowLayout{table: t})
+	t.addCells(row)
+	return row
+}
+
+// adds or removes cells of the row to match the columns
+func (t *TableList) addCells(row *fyne.Container) {
+	if len(row.Objects) > len(t.Columns) {
+		row.Objects = row.Objects[:len(t.Columns)]
+	}
+	f := t.CreateCell
+	if f == nil {
+		return
+	}
+	for len(row.Objects) < len(t.Columns) {
+		row.Objects = append(row.Objects, f(len(row.Objects)))
+	}
+}
+
+func (t *TableList) updateRow(id ListItemID, o fyne.CanvasObject) {
+	row := o.(*fyne.Container)
+	if len(row.Objects) != len(t.Columns) {
+		t.addCells(row)
+		row.Refresh()
+	}
+	f := t.UpdateCell
+	if f == nil {
+		return
+	}
+	for column, cell := range row.Objects {
+		f(id, column, cell)
+	}
+}
+
+// sets the width of a column, laying out the header and visible rows again
+func (t *TableList) resizeColumn(column int, width float32) {
+	t.Columns[column].Width = fyne.Max(width, minColumnWidth)
+	t.header.Refresh()
+
+	l := t.List
+	if l.scroller == nil {
+		return
+	}
+	lo := l.scroller.Content.(*fyne.Container).Layout.(*listLayout)
+	lo.renderLock.RLock()
+	rows := make([]*fyne.Container, 0, len(lo.visible)+len(lo.pinnedRows))
+	for _, vis := range lo.visible {
+		rows = append(rows, vis.item.child.(*fyne.Container))
+	}
+	for _, pinned := range lo.pinnedRows {
+		rows = append(rows, pinned.item.child.(*fyne.Container))
+	}
+	lo.renderLock.RUnlock()
+	for _, row := range rows {
+		row.Layout.Layout(row.Objects, row.Size())
+	}
+}
+
+// Declare conformity with WidgetRenderer interface.
+var _ fyne.WidgetRenderer = (*tableListRenderer)(nil)
+
+type tableListRenderer struct {
+	table   *TableList
+	objects []fyne.CanvasObject
+}
+
+func (r *tableListRenderer) Layout(size fyne.Size) {
+	headerHeight := r.table.header.MinSize().Height
+	r.table.header.Resize(fyne.NewSize(size.Width, headerHeight))
+	r.table.List.Move(fyne.NewPos(0, headerHeight))
+	r.table.List.Resize(fyne.NewSize(size.Width, size.Height-headerHeight))
+}
+
+func (r *tableListRenderer) MinSize() fyne.Size {
+	header := r.table.header.MinSize()
+	list := r.table.List.MinSize()
+	return fyne.NewSize(fyne.Max(header.Width, list.Width), header.Height+list.Height)
+}
+
+func (r *tableListRenderer) Refresh() {
+	r.table.updateCellWidths()
+	r.table.header.Refresh()
+	r.table.List.Refresh()
+	r.Layout(r.table.Size())
+}
+
+func (r *tableListRenderer) Destroy() {}
+
+func (r *tableListRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+// tableRowLayout places the cells of a row in the columns of the table.
+type tableRowLayout struct {
+	table *TableList
+}
+
+func (l *tableRowLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	x := float32(0)
+	for i, o := range objects {
+		if i >= len(l.table.Columns) {
+			break
+		}
+		width := l.table.columnWidth(i)
+		o.Resize(fyne.NewSize(width, size.Height))
+		o.Move(fyne.NewPos(x, 0))
+		x += width + theme.Padding()
+	}
+}
+
+func (l *tableRowLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	height := float32(0)
+	for _, o := range objects {
+		height = fyne.Max(height, o.MinSize().Height)
+	}
+	return fyne.NewSize(l.table.rowWidth(), height)
+}
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*tableHeader)(nil)
+
+// tableHeader shows the titles of the columns of a table, with dividers between them.
+type tableHeader struct {
+	widget.BaseWidget
+	table *TableList
+
+	titles   []*widget.Label
+	dividers []*columnDivider
+	box      *fyne.Container
+}
+
+func newTableHeader(table *TableList) *tableHeader {
+	h := &tableHeader{table: table}
+	h.box = container.New(&tableHeaderLayout{header: h})
+	h.ExtendBaseWidget(h)
+	h.update()
+	return h
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (h *tableHeader) CreateRenderer() fyne.WidgetRenderer {
+	h.ExtendBaseWidget(h)
+	return widget.NewSimpleRenderer(h.box)
+}
+
+// Refresh updates the titles and lays them out for the current column widths.
+func (h *tableHeader) Refresh() {
+	h.update()
+	h.BaseWidget.Refresh()
+}
+
+// matches the titles and dividers to the columns of the table
+func (h *tableHeader) update() {
+	columns := h.table.Columns
+	for len(h.titles) < len(columns) {
+		title := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+		title.Truncation = fyne.TextTruncateEllipsis
+		h.titles = append(h.titles, title)
+		h.dividers = append(h.dividers, newColumnDivider(h.table, len(h.dividers)))
+	}
+	h.titles = h.titles[:len(columns)]
+	h.dividers = h.dividers[:len(columns)]
+
+	objects := make([]fyne.CanvasObject, 0, 2*len(columns))
+	for i, title := range h.titles {
+		title.SetText(columns[i].Title)
+		objects = append(objects, title)
+	}
+	for _, divider := range h.dividers {
+		objects = append(objects, divider)
+	}
+	h.box.Objects = objects
+}
+
+// tableHeaderLayout places the titles over their columns and the dividers between them.
+type tableHeaderLayout struct {
+	header *tableHeader
+}
+
+func (l *tableHeaderLayout) Layout(_ []fyne.CanvasObject, size fyne.Size) {
+	table := l.header.table
+	padding := theme.Padding()
+	hitWidth := theme.InnerPadding()
+	x := float32(0)
+	for i, title := range l.header.titles {
+		width := table.columnWidth(i)
+		title.Resize(fyne.NewSize(width, size.Height))
+		title.Move(fyne.NewPos(x, 0))
+		x += width + padding
+		divider := l.header.dividers[i]
+		divider.Resize(fyne.NewSize(hitWidth, size.Height))
+		divider.Move(fyne.NewPos(x-(padding+hitWidth)/2, 0))
+	}
+}
+
+func (l *tableHeaderLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	height := float32(0)
+	for _, title := range l.header.titles {
+		height = fyne.Max(height, title.MinSize().Height)
+	}
+	return fyne.NewSize(l.header.table.rowWidth(), height)
+}
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*columnDivider)(nil)
+var _ fyne.Draggable = (*columnDivider)(nil)
+var _ desktop.Cursorable = (*columnDivider)(nil)
+
+// columnDivider is the handle after a column title that resizes the column when dragged.
+type columnDivider struct {
+	widget.BaseWidget
+	table  *TableList
+	column int
+
+	line      *canvas.Rectangle
+	dragWidth float32 // width of the column while it is dragged, before it is limited
+	dragging  bool
+}
+
+func newColumnDivider(table *TableList, column int) *columnDivider {
+	d := &columnDivider{table: table, column: column}
+	d.line = canvas.NewRectangle(theme.SeparatorColor())
+	d.ExtendBaseWidget(d)
+	return d
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (d *columnDivider) CreateRenderer() fyne.WidgetRenderer {
+	d.ExtendBaseWidget(d)
+	return &columnDividerRenderer{divider: d}
+}
+
+// Cursor returns the resize cursor shown over the divider.
+func (d *columnDivider) Cursor() desktop.Cursor {
+	return desktop.HResizeCursor
+}
+
+// Dragged resizes the column as the divider is dragged.
+func (d *columnDivider) Dragged(e *fyne.DragEvent) {
+	if !d.dragging {
+		d.dragging = true
+		d.dragWidth = d.table.columnWidth(d.column)
+	}
+	d.dragWidth += e.Dragged.DX
+	d.table.resizeColumn(d.column, d.dragWidth)
+}
+
+// DragEnd is called when the divider is released.
+func (d *columnDivider) DragEnd() {
+	d.dragging = false
+	d.table.List.Refresh() // update the min size of the rows
+	if f := d.table.OnColumnResized; f != nil {
+		f(d.column, d.table.columnWidth(d.column))
+	}
+}
+
+type columnDividerRenderer struct {
+	divider *columnDivider
+}
+
+func (r *columnDividerRenderer) Layout(size fyne.Size) {
+	thickness := theme.SeparatorThicknessSize()
+	r.divider.line.Resize(fyne.NewSize(thickness, size.Height))
+	r.divider.line.Move(fyne.NewPos((size.Width-thickness)/2, 0))
+}
+
+func (r *columnDividerRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(theme.InnerPadding(), 0)
+}
+
+func (r *columnDividerRenderer) Refresh() {
+	r.divider.line.FillColor = theme.SeparatorColor()
+	r.divider.line.Refresh()
+}
+
+func (r *columnDividerRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.divider.line}
+}
+
+func (r *columnDividerRenderer) Destroy() {
+}
@@ -0,0 +1,81 @@
+package fyneadvancedlist
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// how long the pointer must rest on a row before its tooltip is shown
+const tooltipDelay = 600 * time.Millisecond
+
+// The tooltip is drawn by the list renderer rather than in a pop-up, as a pop-up's overlay
+// would take the hover from the row and hide the tooltip again straight away.
+func (l *listLayout) createTooltip() {
+	l.tooltipBackground = canvas.NewRectangle(theme.OverlayBackgroundColor())
+	l.tooltipBackground.StrokeColor = theme.ShadowColor()
+	l.tooltipBackground.StrokeWidth = 1
+	l.tooltipLabel = widget.NewLabel("")
+	l.tooltip = container.NewStack(l.tooltipBackground, l.tooltipLabel)
+	l.tooltip.Hide()
+}
+
+// starts the timer to show the tooltip of the item, if it has one,
+// with pos the pointer position relative to the list
+func (l *listLayout) scheduleTooltip(id ListItemID, pos fyne.Position) {
+	l.hideTooltip()
+	if l.list.TooltipForItem == nil || l.list.isPlaceholder(id) {
+		return
+	}
+	l.tooltipPos = pos
+	l.tooltipTimer = l.list.afterDelay(tooltipDelay, func() {
+		l.showTooltip(id)
+	})
+}
+
+func (l *listLayout) showTooltip(id ListItemID) {
+	f := l.list.TooltipForItem
+	if f == nil || l.draggingRow >= 0 {
+		return
+	}
+	text := f(id)
+	if text == "" {
+		return
+	}
+	l.tooltipBackground.FillColor = theme.OverlayBackgroundColor()
+	l.tooltipBackground.StrokeColor = theme.ShadowColor()
+	l.tooltipBackground.CornerRadius = theme.InputRadiusSize()
+	l.tooltipLabel.SetText(text)
+	size := l.tooltip.MinSize()
+	listSize := l.list.Size()
+
+	// below the pointer, or above it if there is no room below,
+	// kept within the bounds of the list
+	pos := l.tooltipPos.Add(fyne.NewPos(0, theme.IconInlineSize()))
+	if pos.Y+size.Height > listSize.Height {
+		pos.Y = l.tooltipPos.Y - size.Height
+	}
+	if pos.X+size.Width > listSize.Width {
+		pos.X = listSize.Width - size.Width
+	}
+	pos.X = fyne.Max(pos.X, 0)
+	pos.Y = fyne.Max(pos.Y, 0)
+	l.tooltip.Resize(size)
+	l.tooltip.Move(pos)
+	l.tooltip.Show()
+	l.tooltip.Refresh()
+}
+
+func (l *listLayout) hideTooltip() {
+	if l.tooltipTimer != nil {
+		l.tooltipTimer.Stop()
+		l.tooltipTimer = nil
+	}
+	if l.tooltip.Visible() {
+		l.tooltip.Hide()
+	}
+}
@@ -0,0 +1,543 @@
+package fyneadvancedlist
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// TreeNodeID uniquely identifies a node within a tree.
+//
+// Since: Not a core Fyne list API
+type TreeNodeID = string
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*TreeList)(nil)
+
+// TreeList is a widget that shows a hierarchy of nodes as the rows of a List, indenting each node
+// by its depth below an expander arrow for branches. The Left and Right keys close and open the
+// focused branch. If dragging is enabled on the List, nodes can be dropped between rows, or into
+// a branch by dropping them onto the middle of its row, and OnNodeMoved is called.
+//
+// Since: Not a core Fyne list API
+type TreeList struct {
+	widget.BaseWidget
+
+	// List shows the visible nodes of the tree. Set its fields, such as EnableDragging,
+	// to use its features. Its selection and row IDs follow the visible nodes.
+	List *List
+
+	// Root is the node whose children are shown at the top level. It is not shown itself.
+	Root TreeNodeID
+
+	ChildUIDs  func(uid TreeNodeID) []TreeNodeID                         `json:"-"`
+	IsBranch   func(uid TreeNodeID) bool                                 `json:"-"`
+	CreateNode func(branch bool) fyne.CanvasObject                       `json:"-"`
+	UpdateNode func(uid TreeNodeID, branch bool, node fyne.CanvasObject) `json:"-"`
+
+	OnSelected     func(uid TreeNodeID) `json:"-"`
+	OnUnselected   func(uid TreeNodeID) `json:"-"`
+	OnBranchOpened func(uid TreeNodeID) `json:"-"`
+	OnBranchClosed func(uid TreeNodeID) `json:"-"`
+
+	// OnNodeMoved is called when a node is dragged and dropped, with its new parent and the
+	// index among the parent's current children that it was dropped before. The tree does
+	// not change the data; move the node and call Refresh.
+	OnNodeMoved func(uid, parent TreeNodeID, index int) `json:"-"`
+
+	open  map[TreeNodeID]bool
+	nodes []treeNode         // the visible nodes in order
+	ids   map[TreeNodeID]int // the index of each visible node in nodes
+}
+
+// treeNode is a visible node of a tree.
+type treeNode struct {
+	uid, parent TreeNodeID
+	depth       int
+	index       int // among the children of its parent
+	branch      bool
+}
+
+// NewTreeList creates and returns a tree widget for displaying the nodes returned by childUIDs,
+// starting with the children of the root node "".
+//
+// Since: Not a core Fyne list API
+func NewTreeList(childUIDs func(uid TreeNodeID) []TreeNodeID, isBranch func(uid TreeNodeID) bool, createNode func(branch bool) fyne.CanvasObject, updateNode func(uid TreeNodeID, branch bool, node fyne.CanvasObject)) *TreeList {
+	t := &TreeList{ChildUIDs: childUIDs, IsBranch: isBranch, CreateNode: createNode, UpdateNode: updateNode}
+	t.List = NewList(func() int { return len(t.nodes) }, t.createRow, t.updateRow)
+	t.List.OnSelected = func(id ListItemID) {
+		if f := t.OnSelected; f != nil && id < len(t.nodes) {
+			f(t.nodes[id].uid)
+		}
+	}
+	t.List.OnUnselected = func(id ListItemID) {
+		if f := t.OnUnselected; f != nil && id < len(t.nodes) {
+			f(t.nodes[id].uid)
+		}
+	}
+	t.List.keyHandler = t.typedKey
+	t.List.canDropInto = t.canDropInto
+	t.List.dropHandler = t.dropped
+	t.ExtendBaseWidget(t)
+	return t
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (t *TreeList) CreateRenderer() fyne.WidgetRenderer {
+	t.ExtendBaseWidget(t)
+	t.updateNodes()
+	return &treeListRenderer{tree: t, objects: []fyne.CanvasObject{t.List}}
+}
+
+// OpenBranch shows the children of the branch.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) OpenBranch(uid TreeNodeID) {
+	t.setBranchOpen(uid, true)
+}
+
+// CloseBranch hides the children of the branch.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) CloseBranch(uid TreeNodeID) {
+	t.setBranchOpen(uid, false)
+}
+
+// ToggleBranch opens the branch if it is closed, or closes it if it is open.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) ToggleBranch(uid TreeNodeID) {
+	t.setBranchOpen(uid, !t.IsBranchOpen(uid))
+}
+
+// IsBranchOpen returns whether the children of the branch are shown.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) IsBranchOpen(uid TreeNodeID) bool {
+	return t.open[uid]
+}
+
+// Select selects the node, opening the branches above it so that it is visible.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) Select(uid TreeNodeID) {
+	if _, ok := t.ids[uid]; !ok {
+		t.openParents(uid)
+	}
+	if id, ok := t.ids[uid]; ok {
+		t.List.Select(id)
+	}
+}
+
+// ScrollTo scrolls to the node, if it is visible.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) ScrollTo(uid TreeNodeID) {
+	if id, ok := t.ids[uid]; ok {
+		t.List.ScrollTo(id)
+	}
+}
+
+// NodeForID returns the node shown in the row with the given ID of the List.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) NodeForID(id ListItemID) (uid TreeNodeID, ok bool) {
+	if id < 0 || id >= len(t.nodes) {
+		return "", false
+	}
+	return t.nodes[id].uid, true
+}
+
+// IDForNode returns the ID of the row of the List that shows the node. ok is false
+// if the node is not visible because a branch above it is closed.
+//
+// Since: Not a core Fyne list API
+func (t *TreeList) IDForNode(uid TreeNodeID) (id ListItemID, ok bool) {
+	id, ok = t.ids[uid]
+	return id, ok
+}
+
+func (t *TreeList) setBranchOpen(uid TreeNodeID, open bool) {
+	if t.open[uid] == open {
+		return
+	}
+	if open {
+		if t.open == nil {
+			t.open = make(map[TreeNodeID]bool)
+		}
+		t.open[uid] = true
+	} else {
+		delete(t.open, uid)
+	}
+	t.updateNodes()
+	t.List.Refresh()
+	if open {
+		if f := t.OnBranchOpened; f != nil {
+			f(uid)
+		}
+	} else if f := t.OnBranchClosed; f != nil {
+		f(uid)
+	}
+}
+
+// opens the branches above the node, searching the whole tree for it
+func (t *TreeList) openParents(uid TreeNodeID) {
+	var path []TreeNodeID
+	var find func(parent TreeNodeID) bool
+	find = func(parent TreeNodeID) bool {
+		for _, child := range t.childUIDs(parent) {
+			if child == uid {
+				return true
+			}
+			if t.isBranch(child) {
+				path = append(path, child)
+				if find(child) {
+					return true
+				}
+				path = path[:len(path)-1]
+			}
+		}
+		return false
+	}
+	if !find(t.Root) || len(path) == 0 {
+		return
+	}
+	if t.open == nil {
+		t.open = make(map[TreeNodeID]bool)
+	}
+	for _, branch := range path {
+		t.open[branch] = true
+	}
+	t.updateNodes()
+	t.List.Refresh()
+}
+
+func (t *TreeList) childUIDs(uid TreeNodeID) []TreeNodeID {
+	if f := t.ChildUIDs; f != nil {
+		return f(uid)
+	}
+	return nil
+}
+
+func (t *TreeList) isBranch(uid TreeNodeID) bool {
+	if f := t.IsBranch; f != nil {
+		return f(uid)
+	}
+	return false
+}
+
+// finds the visible nodes, keeping the selection and focus of the list on the same nodes
+func (t *TreeList) updateNodes() {
+	l := t.List
+	selected := make([]TreeNodeID, 0, len(l.selected))
+	for _, id := range l.selected {
+		if id < len(t.nodes) {
+			selected = append(selected, t.nodes[id].uid)
+		}
+	}
+	var focusPath []TreeNodeID // the focused node and the branches above it
+	for uid, ok := t.NodeForID(l.currentFocus); ok; uid, ok = t.parentOf(uid) {
+		focusPath = append(focusPath, uid)
+	}
+
+	t.ids = make(map[TreeNodeID]int, len(t.nodes))
+	t.nodes = t.nodes[:0]
+	var walk func(parent TreeNodeID, depth int)
+	walk = func(parent TreeNodeID, depth int) {
+		for i, uid := range t.childUIDs(parent) {
+			branch := t.isBranch(uid)
+			t.ids[uid] = len(t.nodes)
+			t.nodes = append(t.nodes, treeNode{uid: uid, parent: parent, depth: depth, index: i, branch: branch})
+			if branch && t.open[uid] {
+				walk(uid, depth+1)
+			}
+		}
+	}
+	walk(t.Root, 0)
+
+	l.selected = l.selected[:0]
+	for _, uid := range selected {
+		if id, ok := t.ids[uid]; ok {
+			l.selected = append(l.selected, id)
+		}
+	}
+	// move the focus to the nearest visible branch if its node is hidden
+	for _, uid := range focusPath {
+		if id, ok := t.ids[uid]; ok {
+			l.currentFocus = id
+			break
+		}
+	}
+}
+
+// returns the parent of the node, if it has been visible
+func (t *TreeList) parentOf(uid TreeNodeID) (TreeNodeID, bool) {
+	if id, ok := t.ids[uid]; ok && t.nodes[id].parent != t.Root {
+		return t.nodes[id].parent, true
+	}
+	return "", false
+}
+
+// returns whether ancestor is above the visible node uid in the tree
+func (t *TreeList) isAncestor(ancestor, uid TreeNodeID) bool {
+	for {
+		parent, ok := t.parentOf(uid)
+		if !ok {
+			return false
+		}
+		if parent == ancestor {
+			return true
+		}
+		uid = parent
+	}
+}
+
+// opens and closes branches with the Right and Left keys, moving into and out of them
+func (t *TreeList) typedKey(event *fyne.KeyEvent) bool {
+	l := t.List
+	uid, ok := t.NodeForID(l.currentFocus)
+	if !ok {
+		return false
+	}
+	node := t.nodes[l.currentFocus]
+	switch event.Name {
+	case fyne.KeyRight:
+		if !node.branch {
+			return true
+		}
+		if !t.open[uid] {
+			t.OpenBranch(uid)
+		} else if next := l.currentFocus + 1; next < len(t.nodes) && t.nodes[next].parent == uid {
+			l.moveFocus(next) // the first child
+		}
+		return true
+	case fyne.KeyLeft:
+		if node.branch && t.open[uid] {
+			t.CloseBranch(uid)
+		} else if parent, ok := t.parentOf(uid); ok {
+			l.moveFocus(t.ids[parent])
+		}
+		return true
+	}
+	return false
+}
+
+// returns whether the dragged node can be dropped into the node in the row id
+func (t *TreeList) canDropInto(id ListItemID) bool {
+	dragged, ok := t.List.DraggingItem()
+	if !ok || id >= len(t.nodes) || !t.nodes[id].branch {
+		return false
+	}
+	uid := t.nodes[dragged].uid
+	return t.nodes[id].uid != uid && !t.isAncestor(uid, t.nodes[id].uid)
+}
+
+// works out where a dragged node was dropped and reports it to OnNodeMoved
+func (t *TreeList) dropped(from, insertAt, into ListItemID) {
+	f := t.OnNodeMoved
+	if f == nil || from >= len(t.nodes) {
+		return
+	}
+	node := t.nodes[from]
+	var parent TreeNodeID
+	var index int
+	if into >= 0 && into < len(t.nodes) {
+		parent = t.nodes[into].uid
+		index = len(t.childUIDs(parent))
+	} else if insertAt < len(t.nodes) {
+		parent, index = t.nodes[insertAt].parent, t.nodes[insertAt].index
+	} else {
+		parent, index = t.Root, len(t.childUIDs(t.Root))
+	}
+	if parent == node.uid || t.isAncestor(node.uid, parent) {
+		return // can't move a branch inside itself
+	}
+	if parent == node.parent && (index == node.index || index == node.index+1) {
+		return // dropped where it was
+	}
+	f(node.uid, parent, index)
+}
+
+// returns a row showing a node, which holds content for both branches and leaves
+func (t *TreeList) createRow() fyne.CanvasObject {
+	var branch, leaf fyne.CanvasObject
+	if f := t.CreateNode; f != nil {
+		branch, leaf = f(true), f(false)
+	}
+	return newTreeRow(t, branch, leaf)
+}
+
+func (t *TreeList) updateRow(id ListItemID, o fyne.CanvasObject) {
+	if id >= len(t.nodes) {
+		return
+	}
+	row := o.(*treeRow)
+	node := t.nodes[id]
+	row.setNode(node, t.open[node.uid])
+	if f := t.UpdateNode; f != nil && row.content() != nil {
+		f(node.uid, node.branch, row.content())
+	}
+}
+
+// Declare conformity with WidgetRenderer interface.
+var _ fyne.WidgetRenderer = (*treeListRenderer)(nil)
+
+type treeListRenderer struct {
+	tree    *TreeList
+	objects []fyne.CanvasObject
+}
+
+func (r *treeListRenderer) Layout(size fyne.Size) {
+	r.tree.List.Resize(size)
+}
+
+func (r *treeListRenderer) MinSize() fyne.Size {
+	return r.tree.List.MinSize()
+}
+
+func (r *treeListRenderer) Refresh() {
+	r.tree.updateNodes()
+	r.tree.List.Refresh()
+}
+
+func (r *treeListRenderer) Destroy() {}
+
+func (r *treeListRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*treeRow)(nil)
+
+// treeRow shows a node indented by its depth, after an expander arrow if it is a branch.
+type treeRow struct {
+	widget.BaseWidget
+	tree *TreeList
+
+	node         treeNode
+	expander     *treeExpander
+	branch, leaf fyne.CanvasObject
+	box          *fyne.Container
+}
+
+func newTreeRow(tree *TreeList, branch, leaf fyne.CanvasObject) *treeRow {
+	r := &treeRow{tree: tree, branch: branch, leaf: leaf}
+	r.expander = newTreeExpander(tree)
+	objects := []fyne.CanvasObject{r.expander}
+	for _, o := range []fyne.CanvasObject{branch, leaf} {
+		if o != nil {
+			o.Hide()
+			objects = append(objects, o)
+		}
+	}
+	r.box = container.New(&treeRowLayout{row: r}, objects...)
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (r *treeRow) CreateRenderer() fyne.WidgetRenderer {
+	r.ExtendBaseWidget(r)
+	return widget.NewSimpleRenderer(r.box)
+}
+
+// returns the content showing the node of the row
+func (r *treeRow) content() fyne.CanvasObject {
+	if r.node.branch {
+		return r.branch
+	}
+	return r.leaf
+}
+
+// shows the node in the row
+func (r *treeRow) setNode(node treeNode, open bool) {
+	relayout := node.depth != r.node.depth || node.branch != r.node.branch
+	r.node = node
+	r.expander.uid = node.uid
+	if node.branch {
+		r.expander.setOpen(open)
+		r.expander.Show()
+	} else {
+		r.expander.Hide()
+	}
+	for _, o := range []fyne.CanvasObject{r.branch, r.leaf} {
+		if o == nil {
+			continue
+		}
+		if o == r.content() {
+			o.Show()
+		} else {
+			o.Hide()
+		}
+	}
+	if relayout {
+		r.box.Refresh()
+	}
+}
+
+// treeRowLayout indents the content of a tree row by its depth, after the expander.
+type treeRowLayout struct {
+	row *treeRow
+}
+
+func (l *treeRowLayout) Layout(_ []fyne.CanvasObject, size fyne.Size) {
+	iconSize := theme.IconInlineSize()
+	padding := theme.Padding()
+	x := float32(l.row.node.depth) * (iconSize + padding)
+	l.row.expander.Resize(fyne.NewSquareSize(iconSize))
+	l.row.expander.Move(fyne.NewPos(x, (size.Height-iconSize)/2))
+	x += iconSize + padding
+	if content := l.row.content(); content != nil {
+		content.Resize(fyne.NewSize(fyne.Max(size.Width-x, 0), size.Height))
+		content.Move(fyne.NewPos(x, 0))
+	}
+}
+
+func (l *treeRowLayout) MinSize([]fyne.CanvasObject) fyne.Size {
+	iconSize := theme.IconInlineSize()
+	min := fyne.NewSquareSize(iconSize)
+	for _, o := range []fyne.CanvasObject{l.row.branch, l.row.leaf} {
+		if o != nil {
+			min = min.Max(o.MinSize())
+		}
+	}
+	return fyne.NewSize(min.Width+iconSize+theme.Padding(), min.Height)
+}
+
+// Declare conformity with interfaces.
+var _ fyne.Widget = (*treeExpander)(nil)
+var _ fyne.Tappable = (*treeExpander)(nil)
+
+// treeExpander is the arrow before a branch that opens or closes it when tapped.
+type treeExpander struct {
+	widget.BaseWidget
+	tree *TreeList
+	uid  TreeNodeID
+	icon *widget.Icon
+}
+
+func newTreeExpander(tree *TreeList) *treeExpander {
+	e := &treeExpander{tree: tree, icon: widget.NewIcon(theme.NavigateNextIcon())}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// CreateRenderer is a private method to Fyne which links this widget to its renderer.
+func (e *treeExpander) CreateRenderer() fyne.WidgetRenderer {
+	e.ExtendBaseWidget(e)
+	return widget.NewSimpleRenderer(e.icon)
+}
+
+// Tapped opens or closes the branch.
+func (e *treeExpander) Tapped(*fyne.PointEvent) {
+	e.tree.ToggleBranch(e.uid)
+}
+
+func (e *treeExpander) setOpen(open bool) {
+	if open {
+		e.icon.SetResource(theme.MoveDownIcon())
+	} else {
+		e.icon.SetResource(theme.NavigateNextIcon())
+	}
+}